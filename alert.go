@@ -0,0 +1,249 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/apd/v3"
+	"github.com/tgulacsi/mnbarf/mnb"
+)
+
+// errAlertsFired is returned by the alert command when some rules fired, to exit with a non-zero status.
+var errAlertsFired = errors.New("alerts fired")
+
+// alertRule is a rule of the alerts file.
+//
+// With Currency, the rule checks the per unit rate of the currency, in HUF;
+// with BaseRate, it checks the central bank base rate, in percent.
+// Above and Below are thresholds, ChangePercent is the maximum move
+// (in either direction) since the previous publication day,
+// and Changed fires on any change since the previous publication day.
+type alertRule struct {
+	Name          string
+	Currency      string
+	BaseRate      bool
+	Above, Below  *mnb.Double
+	ChangePercent *mnb.Double
+	Changed       bool
+}
+
+// alert is a fired rule.
+type alert struct {
+	Rule     string
+	Day      mnb.Date
+	Currency string `json:",omitempty"`
+	Value    mnb.Double
+	Previous *mnb.Double `json:",omitempty"`
+	Message  string
+}
+
+func (a alert) String() string { return a.Rule + ": " + a.Message }
+
+// alertInput holds the rates the rules are evaluated on.
+type alertInput struct {
+	Prev, Cur         mnb.DayRates
+	PrevBase, CurBase mnb.MNBBaseRate
+}
+
+// readAlertRules reads the rules from the JSON file, which is an array of alertRule objects, like
+//
+//	[{"Currency": "EUR", "ChangePercent": 1},
+//	 {"Name": "USD hedge", "Currency": "USD", "Above": 400},
+//	 {"BaseRate": true, "Changed": true}]
+func readAlertRules(fn string) ([]alertRule, error) {
+	b, err := os.ReadFile(fn)
+	if err != nil {
+		return nil, err
+	}
+	var rules []alertRule
+	if err = json.Unmarshal(b, &rules); err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+	for i, r := range rules {
+		r.Currency = strings.ToUpper(strings.TrimSpace(r.Currency))
+		if (r.Currency == "") == !r.BaseRate {
+			return nil, fmt.Errorf("%s: rule %d: exactly one of Currency or BaseRate is needed", fn, i+1)
+		}
+		if r.Above == nil && r.Below == nil && r.ChangePercent == nil && !r.Changed {
+			return nil, fmt.Errorf("%s: rule %d: no condition (Above, Below, ChangePercent or Changed)", fn, i+1)
+		}
+		if r.Name == "" {
+			r.Name = r.Currency
+			if r.BaseRate {
+				r.Name = "base rate"
+			}
+		}
+		rules[i] = r
+	}
+	return rules, nil
+}
+
+// Evaluate returns the alerts of the rule for the input.
+func (r alertRule) Evaluate(in alertInput) ([]alert, error) {
+	a := alert{Rule: r.Name, Day: in.Cur.Day, Currency: r.Currency}
+	what := r.Currency
+	if r.BaseRate {
+		what = "base rate"
+		a.Value = in.CurBase.Rate
+		if in.PrevBase.Rate.Decimal != nil {
+			a.Previous = &in.PrevBase.Rate
+		}
+	} else {
+		rate, ok := in.Cur.Find(r.Currency)
+		if !ok {
+			return nil, fmt.Errorf("%s: %w", r.Currency, mnb.ErrNoRate)
+		}
+		var err error
+		if a.Value, err = rate.PerUnit(); err != nil {
+			return nil, err
+		}
+		if rate, ok = in.Prev.Find(r.Currency); ok {
+			prev, err := rate.PerUnit()
+			if err != nil {
+				return nil, err
+			}
+			a.Previous = &prev
+		}
+	}
+	if a.Value.Decimal == nil {
+		return nil, fmt.Errorf("%s: %w", what, mnb.ErrNoRate)
+	}
+
+	var alerts []alert
+	fire := func(format string, args ...any) {
+		b := a
+		b.Message = what + " " + fmt.Sprintf(format, args...)
+		alerts = append(alerts, b)
+	}
+	if r.Above != nil && a.Value.Cmp(r.Above.Decimal) > 0 {
+		fire("%s is above %s", a.Value.String(), r.Above.String())
+	}
+	if r.Below != nil && a.Value.Cmp(r.Below.Decimal) < 0 {
+		fire("%s is below %s", a.Value.String(), r.Below.String())
+	}
+	if a.Previous == nil {
+		return alerts, nil
+	}
+	if r.Changed && a.Value.Cmp(a.Previous.Decimal) != 0 {
+		fire("changed from %s to %s", a.Previous.String(), a.Value.String())
+	}
+	if r.ChangePercent != nil {
+		pct, err := mnb.PercentChange(*a.Previous, a.Value)
+		if err != nil {
+			return alerts, err
+		}
+		var abs apd.Decimal
+		abs.Abs(pct.Decimal)
+		if abs.Cmp(r.ChangePercent.Decimal) > 0 {
+			fire("moved %s%% (from %s to %s), more than %s%%", pct.Round(2).String(), a.Previous.String(), a.Value.String(), r.ChangePercent.String())
+		}
+	}
+	return alerts, nil
+}
+
+// alertCurrencies returns the currencies the rules check.
+func alertCurrencies(rules []alertRule) []string {
+	var currencies []string
+	for _, r := range rules {
+		if r.Currency != "" && !slices.Contains(currencies, r.Currency) {
+			currencies = append(currencies, r.Currency)
+		}
+	}
+	return currencies
+}
+
+// evaluateAlerts evaluates all the rules.
+func evaluateAlerts(rules []alertRule, in alertInput) ([]alert, error) {
+	var alerts []alert
+	var errs []error
+	for _, r := range rules {
+		aa, err := r.Evaluate(in)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", r.Name, err))
+		}
+		alerts = append(alerts, aa...)
+	}
+	return alerts, errors.Join(errs...)
+}
+
+// checkAlerts evaluates the rules on the day's rates, compared to the previous publication day.
+func checkAlerts(ctx context.Context, wsC mnb.MNBArfolyamService, wsR mnb.MNBAlapkamatService, rules []alertRule, cur mnb.DayRates) ([]alert, error) {
+	in, err := alertInputs(ctx, wsC, wsR, rules, cur)
+	if err != nil {
+		return nil, err
+	}
+	return evaluateAlerts(rules, in)
+}
+
+// alertInputs returns the rates the rules are evaluated on: the day's, and the previous publication day's.
+func alertInputs(ctx context.Context, wsC mnb.MNBArfolyamService, wsR mnb.MNBAlapkamatService, rules []alertRule, cur mnb.DayRates) (alertInput, error) {
+	in := alertInput{Cur: cur}
+	var needBase bool
+	for _, r := range rules {
+		needBase = needBase || r.BaseRate
+	}
+	// the previous publication day is needed for the base rate rules, too
+	currencies := alertCurrencies(rules)
+	if len(currencies) == 0 {
+		for _, r := range cur.Rates {
+			currencies = append(currencies, r.Currency)
+		}
+	}
+	var err error
+	if in.Prev, err = wsC.GetExchangeRatesAt(ctx, time.Time(cur.Day).AddDate(0, 0, -1), currencies...); err != nil && !errors.Is(err, mnb.ErrNoRate) {
+		return in, err
+	}
+	if needBase {
+		if in.CurBase, err = wsR.GetBaseRateAt(ctx, time.Time(cur.Day)); err != nil {
+			return in, err
+		}
+		if !time.Time(in.Prev.Day).IsZero() {
+			if in.PrevBase, err = wsR.GetBaseRateAt(ctx, time.Time(in.Prev.Day)); err != nil && !errors.Is(err, mnb.ErrNoRate) {
+				return in, err
+			}
+		}
+	}
+	return in, nil
+}
+
+// alertAction is the daemon action evaluating the rules on the new rates, and firing the hooks with the alerts.
+// Only the failure to get the rates fails the action, as then no alert is fired yet, so a retry is safe.
+// The errors of the rules (such as a missing currency) and of the hooks (which keep the undeliverable events
+// in the dead letter file) are only logged: a retry would fire the alerts of the other rules again.
+func alertAction(wsC mnb.MNBArfolyamService, wsR mnb.MNBAlapkamatService, rules []alertRule, hooks *hookDispatcher) daemonAction {
+	return daemonAction{Name: "alerts", Do: func(ctx context.Context, day mnb.DayRates) error {
+		in, err := alertInputs(ctx, wsC, wsR, rules, day)
+		if err != nil {
+			return err
+		}
+		alerts, err := evaluateAlerts(rules, in)
+		if err != nil {
+			logger.Error("alert rules", "day", day.Day, "error", err)
+		}
+		if err := reportAlerts(ctx, hooks, alerts); err != nil {
+			logger.Error("alert hooks", "day", day.Day, "error", err)
+		}
+		return nil
+	}}
+}
+
+// reportAlerts prints the alerts to stderr and fires the hooks with them.
+func reportAlerts(ctx context.Context, hooks *hookDispatcher, alerts []alert) error {
+	if len(alerts) == 0 {
+		return nil
+	}
+	for _, a := range alerts {
+		fmt.Fprintln(os.Stderr, "ALERT", a.Day, a.String())
+	}
+	return hooks.Fire(ctx, hookEvent{Event: "alert", Detected: time.Now(), Alerts: alerts})
+}
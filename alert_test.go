@@ -0,0 +1,160 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/tgulacsi/mnbarf/mnb"
+)
+
+func TestAlertRuleEvaluate(t *testing.T) {
+	limit := func(s string) *mnb.Double {
+		d, err := mnb.NewDoubleFromString(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &d
+	}
+	days := testRates(t,
+		"2024-05-02 EUR=390 JPY/100=240",
+		"2024-05-03 EUR=395 JPY/100=236.5 USD=364.10",
+	)
+	in := alertInput{Prev: days[0], Cur: days[1],
+		PrevBase: mnb.MNBBaseRate{Rate: *limit("6.5")}, CurBase: mnb.MNBBaseRate{Rate: *limit("6.25")}}
+	for _, tc := range []struct {
+		Name string
+		Rule alertRule
+		In   *alertInput
+		Want []string
+	}{
+		{Name: "above", Rule: alertRule{Currency: "EUR", Above: limit("394")}, Want: []string{"EUR 395 is above 394"}},
+		{Name: "not above", Rule: alertRule{Currency: "EUR", Above: limit("395")}},
+		{Name: "below", Rule: alertRule{Currency: "EUR", Below: limit("396")}, Want: []string{"EUR 395 is below 396"}},
+		{Name: "not below", Rule: alertRule{Currency: "EUR", Below: limit("395")}},
+		{Name: "per unit", Rule: alertRule{Currency: "JPY", Above: limit("2.3")}, Want: []string{"JPY 2.365 is above 2.3"}},
+		{Name: "change percent", Rule: alertRule{Currency: "EUR", ChangePercent: limit("1")},
+			Want: []string{"EUR moved 1.28% (from 390 to 395), more than 1%"}},
+		{Name: "change percent down", Rule: alertRule{Currency: "JPY", ChangePercent: limit("1")},
+			Want: []string{"JPY moved -1.46% (from 2.4 to 2.365), more than 1%"}},
+		{Name: "small change", Rule: alertRule{Currency: "EUR", ChangePercent: limit("2")}},
+		{Name: "changed", Rule: alertRule{Currency: "EUR", Changed: true}, Want: []string{"EUR changed from 390 to 395"}},
+		{Name: "no previous", Rule: alertRule{Currency: "USD", Changed: true, ChangePercent: limit("0")}},
+		{Name: "all", Rule: alertRule{Currency: "EUR", Above: limit("394"), Changed: true},
+			Want: []string{"EUR 395 is above 394", "EUR changed from 390 to 395"}},
+		{Name: "base rate changed", Rule: alertRule{BaseRate: true, Changed: true},
+			Want: []string{"base rate changed from 6.5 to 6.25"}},
+		{Name: "base rate below", Rule: alertRule{BaseRate: true, Below: limit("6.5")}, Want: []string{"base rate 6.25 is below 6.5"}},
+		{Name: "base rate unchanged", Rule: alertRule{BaseRate: true, Changed: true},
+			In: &alertInput{Cur: days[1], PrevBase: in.CurBase, CurBase: in.CurBase}},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			in := in
+			if tc.In != nil {
+				in = *tc.In
+			}
+			tc.Rule.Name = tc.Name
+			alerts, err := tc.Rule.Evaluate(in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, a := range alerts {
+				got = append(got, a.Message)
+				if a.Rule != tc.Name || a.Day.String() != "2024-05-03" {
+					t.Errorf("got rule %q on %s", a.Rule, a.Day)
+				}
+			}
+			if g, w := strings.Join(got, "\n"), strings.Join(tc.Want, "\n"); g != w {
+				t.Errorf("got\n%s\nwanted\n%s", g, w)
+			}
+		})
+	}
+
+	if _, err := (alertRule{Currency: "GBP", Changed: true}).Evaluate(in); !errors.Is(err, mnb.ErrNoRate) {
+		t.Errorf("GBP: got %+v, wanted %v", err, mnb.ErrNoRate)
+	}
+}
+
+// recordingHook records the delivered events.
+type recordingHook struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (h *recordingHook) Name() string { return "recorder" }
+func (h *recordingHook) Deliver(_ context.Context, event string, _ []byte) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, event)
+	return nil
+}
+
+func (h *recordingHook) Count(event string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var n int
+	for _, e := range h.events {
+		if e == event {
+			n++
+		}
+	}
+	return n
+}
+
+func TestDaemonAlertsNoDuplicates(t *testing.T) {
+	ctx := context.Background()
+	fake := fakeMNB{Days: testRates(t,
+		"2024-05-02 EUR=390.00",
+		"2024-05-03 EUR=395.00",
+	)}
+	srv := fake.serve(t)
+	wsC, wsR := mnb.NewMNBArfolyamService(srv.URL, nil, nil), mnb.NewMNBAlapkamatService(srv.URL, nil, nil)
+	above := mnb.NewDouble(394, 0)
+	rules := []alertRule{
+		{Name: "EUR high", Currency: "EUR", Above: &above},
+		// GBP is not published: the rule fails
+		{Name: "GBP", Currency: "GBP", Changed: true},
+	}
+	rec := &recordingHook{}
+	d := daemon{
+		wsC: wsC, wsR: wsR,
+		StateDir: t.TempDir(),
+		Calendar: mnb.NewCalendar(),
+		Hooks:    &hookDispatcher{Hooks: []hook{rec}},
+	}
+	failing := true
+	d.Actions = []daemonAction{
+		alertAction(wsC, wsR, rules, d.Hooks),
+		{Name: "load", Do: func(context.Context, mnb.DayRates) error {
+			if failing {
+				return errors.New("db is down")
+			}
+			return nil
+		}},
+	}
+
+	err := d.Poll(ctx)
+	if err == nil || !strings.Contains(err.Error(), "db is down") || strings.Contains(err.Error(), "alerts") {
+		t.Fatalf("got %v, wanted the error of the load action only", err)
+	}
+	failing = false
+	if err := d.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if n := rec.Count("alert"); n != 1 {
+		t.Errorf("the alerts were fired %d times, wanted once", n)
+	}
+	if n := rec.Count("rates"); n != 1 {
+		t.Errorf("the rates were fired %d times, wanted once", n)
+	}
+	if got := d.last.String(); got != "2024-05-03" {
+		t.Errorf("got last %s, wanted 2024-05-03", got)
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/tgulacsi/mnbarf/mnb"
+)
+
+// analysisReport is the result of the analyze command.
+type analysisReport struct {
+	Analyses    []mnb.Analysis
+	Correlation mnb.Correlation
+}
+
+// analysisTables are the tables of analyze -show.
+var analysisTables = []string{"summary", "returns", "rolling", "correlation"}
+
+// printAnalysis prints the analysis in the output format:
+// json and the templates get the whole analysisReport (the "row" template each Analysis),
+// the tabular formats the show table.
+func printAnalysis(w io.Writer, report analysisReport, show, outFormat string) error {
+	bw := bufio.NewWriter(w)
+	defer bw.Flush()
+
+	switch outFormat {
+	case "json":
+		enc := json.NewEncoder(bw)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+
+	case "csv", "html", "markdown", "md":
+		t, err := analysisTable(report, show)
+		if err != nil {
+			return err
+		}
+		switch outFormat {
+		case "csv":
+			return t.WriteCSV(bw)
+		case "html":
+			return t.WriteHTML(bw)
+		}
+		return t.WriteMarkdown(bw)
+
+	case "sql":
+		return fmt.Errorf("the sql format is not supported for analysis")
+
+	default: // template
+		tmpl, err := parseOutTemplate(outFormat)
+		if err != nil {
+			logger.Info("template parse", "error", err)
+			return err
+		}
+		if err := executeOutTemplate(bw, tmpl, report, report.Analyses); err != nil {
+			logger.Info("template execute", "error", err)
+			return err
+		}
+	}
+	return nil
+}
+
+func analysisTable(report analysisReport, show string) (table, error) {
+	var t table
+	switch show {
+	case "summary":
+		t = table{
+			Title: "MNB exchange rate returns and volatility",
+			Columns: []string{"currency", "unit", "period", "count", "total_return(%)",
+				"mean_log_return", "stdev", "annualized_volatility(%)"},
+		}
+		for _, a := range report.Analyses {
+			t.Rows = append(t.Rows, []string{
+				a.Currency, strconv.Itoa(a.Unit), periodName(a.Period), strconv.Itoa(a.Count),
+				formatFloat(100*float64(a.TotalReturn), 4), formatFloat(float64(a.MeanLogReturn), 8),
+				formatFloat(float64(a.StdDev), 8), formatFloat(100*float64(a.Volatility), 4),
+			})
+		}
+
+	case "returns":
+		t = table{
+			Title:   "MNB exchange rate returns",
+			Columns: []string{"currency", "from", "day", "rate", "return(%)", "log_return"},
+		}
+		for _, a := range report.Analyses {
+			for _, r := range a.Returns {
+				t.Rows = append(t.Rows, []string{
+					a.Currency, outLocale.FormatDate(r.From), outLocale.FormatDate(r.Day),
+					outLocale.FormatDouble(r.Rate), formatFloat(100*float64(r.Return), 4), formatFloat(float64(r.LogReturn), 8),
+				})
+			}
+		}
+
+	case "rolling":
+		t = table{
+			Title:   "MNB exchange rate rolling volatility",
+			Columns: []string{"currency", "day", "stdev", "annualized_volatility(%)"},
+		}
+		for _, a := range report.Analyses {
+			for _, v := range a.Rolling {
+				t.Rows = append(t.Rows, []string{
+					a.Currency, outLocale.FormatDate(v.Day), formatFloat(float64(v.StdDev), 8), formatFloat(100*float64(v.Annualized), 4),
+				})
+			}
+		}
+
+	case "correlation":
+		t = table{
+			Title:   "MNB exchange rate return correlations",
+			Columns: append([]string{"currency"}, report.Correlation.Currencies...),
+		}
+		for i, row := range report.Correlation.Matrix {
+			cells := []string{report.Correlation.Currencies[i]}
+			for _, c := range row {
+				if c == nil {
+					cells = append(cells, "")
+				} else {
+					cells = append(cells, formatFloat(*c, 4))
+				}
+			}
+			t.Rows = append(t.Rows, cells)
+		}
+
+	default:
+		return t, fmt.Errorf("unknown table %q (one of %q is accepted)", show, analysisTables)
+	}
+	return t, nil
+}
+
+func periodName(p mnb.Period) string {
+	if p == "" {
+		return "day"
+	}
+	return string(p)
+}
+
+// formatFloat formats f rounded to places decimals, in outLocale.
+func formatFloat(f float64, places int) string {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return ""
+	}
+	d, err := mnb.NewDoubleFromString(strconv.FormatFloat(f, 'f', places, 64))
+	if err != nil {
+		return strconv.FormatFloat(f, 'f', places, 64)
+	}
+	return outLocale.FormatDouble(d)
+}
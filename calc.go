@@ -0,0 +1,378 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/tgulacsi/mnbarf/mnb"
+)
+
+// The calc expressions are sums of amounts, such as
+//
+//	100 EUR + 2500 JPY - 30 USD in CHF @ 2024-05-02
+//
+// with multiplication and division by plain numbers, and parentheses.
+// The result is in HUF, if there's no "in", and on today, if there's no "@".
+
+// calcValue is the value of an expression: the amounts by currency,
+// the amount of the empty currency is the plain number.
+type calcValue map[string]mnb.Double
+
+func (v calcValue) isNumber() bool {
+	_, ok := v[""]
+	return ok && len(v) == 1
+}
+
+func (v calcValue) add(w calcValue, sign int) (calcValue, error) {
+	if v.isNumber() != w.isNumber() {
+		return nil, errors.New("cannot add a plain number to an amount: give its currency")
+	}
+	z := maps.Clone(v)
+	for c, a := range w {
+		if sign < 0 {
+			a = a.Neg()
+		}
+		var err error
+		if z[c], err = z[c].Add(a); err != nil {
+			return nil, err
+		}
+	}
+	return z, nil
+}
+
+func (v calcValue) mul(w calcValue, div bool) (calcValue, error) {
+	if !w.isNumber() {
+		if div || !v.isNumber() {
+			return nil, errors.New("amounts can be multiplied and divided by plain numbers only")
+		}
+		v, w = w, v
+	}
+	n := w[""]
+	z := make(calcValue, len(v))
+	for c, a := range v {
+		var err error
+		if div {
+			if n.IsZero() {
+				return nil, errors.New("division by zero")
+			}
+			z[c], err = a.Quo(n)
+		} else {
+			z[c], err = a.Mul(n)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return z, nil
+}
+
+// calcExpr is a parsed calc expression.
+type calcExpr struct {
+	Value calcValue
+	// To is the currency of the result, Day is the day of the rates.
+	To  string
+	Day time.Time
+}
+
+// calcParser is a recursive descent parser of the calc expressions.
+type calcParser struct {
+	s   string
+	pos int
+}
+
+// parseCalc parses the expression.
+func parseCalc(s string, today time.Time) (calcExpr, error) {
+	p := calcParser{s: s}
+	e := calcExpr{To: "HUF", Day: today}
+	var err error
+	if e.Value, err = p.sum(); err != nil {
+		return e, err
+	}
+	for {
+		switch tok := p.peek(); {
+		case tok == "":
+			if e.Value.isNumber() {
+				// a plain number is in HUF
+				e.Value = calcValue{"HUF": e.Value[""]}
+			}
+			return e, nil
+		case tok == "@":
+			p.next()
+			dayS := p.word(func(r rune) bool { return !unicode.IsSpace(r) })
+			if e.Day, err = parseDay(dayS); err != nil {
+				return e, err
+			}
+		case strings.EqualFold(tok, "in") || strings.EqualFold(tok, "to"):
+			p.next()
+			if e.To = p.currency(); e.To == "" {
+				return e, p.errorf("currency is expected")
+			}
+		default:
+			return e, p.errorf("unexpected %q", tok)
+		}
+	}
+}
+
+func (p *calcParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("at %d: "+format, append([]any{p.pos + 1}, args...)...)
+}
+
+func (p *calcParser) skipSpace() {
+	for p.pos < len(p.s) && unicode.IsSpace(rune(p.s[p.pos])) {
+		p.pos++
+	}
+}
+
+// peek returns the next token: a symbol, a number or a word.
+func (p *calcParser) peek() string {
+	p.skipSpace()
+	if p.pos >= len(p.s) {
+		return ""
+	}
+	rest := p.s[p.pos:]
+	switch c := rune(rest[0]); {
+	case strings.ContainsRune("+-*/()@", c):
+		return rest[:1]
+	case unicode.IsDigit(c) || c == '.':
+		return rest[:len(rest)-len(strings.TrimLeftFunc(rest, isNumberRune))]
+	case unicode.IsLetter(c):
+		return rest[:len(rest)-len(strings.TrimLeftFunc(rest, unicode.IsLetter))]
+	}
+	return rest[:1]
+}
+
+func (p *calcParser) next() string {
+	tok := p.peek()
+	p.pos += len(tok)
+	return tok
+}
+
+func (p *calcParser) word(f func(rune) bool) string {
+	p.skipSpace()
+	rest := p.s[p.pos:]
+	w := rest[:len(rest)-len(strings.TrimLeftFunc(rest, f))]
+	p.pos += len(w)
+	return w
+}
+
+func isNumberRune(r rune) bool { return unicode.IsDigit(r) || r == '.' || r == '_' }
+
+// currency returns the next token if it's a currency code.
+func (p *calcParser) currency() string {
+	tok := p.peek()
+	if len(tok) != 3 {
+		return ""
+	}
+	for _, r := range tok {
+		if !unicode.IsLetter(r) {
+			return ""
+		}
+	}
+	p.next()
+	return strings.ToUpper(tok)
+}
+
+// sum := prod (('+'|'-') prod)*
+func (p *calcParser) sum() (calcValue, error) {
+	v, err := p.prod()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok := p.peek()
+		if tok != "+" && tok != "-" {
+			return v, nil
+		}
+		p.next()
+		w, err := p.prod()
+		if err != nil {
+			return nil, err
+		}
+		sign := 1
+		if tok == "-" {
+			sign = -1
+		}
+		if v, err = v.add(w, sign); err != nil {
+			return nil, p.errorf("%w", err)
+		}
+	}
+}
+
+// prod := unary (('*'|'/') unary)*
+func (p *calcParser) prod() (calcValue, error) {
+	v, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok := p.peek()
+		if tok != "*" && tok != "/" {
+			return v, nil
+		}
+		p.next()
+		w, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		if v, err = v.mul(w, tok == "/"); err != nil {
+			return nil, p.errorf("%w", err)
+		}
+	}
+}
+
+// unary := '-' unary | primary
+// primary := NUMBER [CURRENCY] | CURRENCY NUMBER | '(' sum ')'
+func (p *calcParser) unary() (calcValue, error) {
+	switch tok := p.peek(); {
+	case tok == "-":
+		p.next()
+		v, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return v.mul(calcValue{"": mnb.NewDouble(-1, 0)}, false)
+	case tok == "(":
+		p.next()
+		v, err := p.sum()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, p.errorf("missing )")
+		}
+		return v, nil
+	case tok == "":
+		return nil, p.errorf("unexpected end")
+	}
+	curr := p.currency()
+	tok := p.peek()
+	if tok == "" || !isNumberRune(rune(tok[0])) {
+		return nil, p.errorf("number is expected instead of %q", tok)
+	}
+	p.next()
+	var n mnb.Double
+	if err := n.UnmarshalText([]byte(strings.ReplaceAll(tok, "_", ""))); err != nil {
+		return nil, p.errorf("%w", err)
+	}
+	if curr == "" {
+		curr = p.currency()
+	}
+	return calcValue{curr: n}, nil
+}
+
+// calcLine is the conversion of the amount of a currency.
+type calcLine struct {
+	Currency string
+	Amount   mnb.Double
+	// Unit and Rate are the MNB quote: the HUF value of Unit of the currency.
+	Unit int
+	Rate mnb.Double
+	// CrossRate is the value of one unit of the currency in the result currency, Value is the Amount in it.
+	CrossRate, Value mnb.Double
+}
+
+// calcResult is the result of a calc expression, with the breakdown of the rates.
+type calcResult struct {
+	Expression string
+	// Day is the publication day of the rates used.
+	Day    mnb.Date
+	To     string
+	Result mnb.Double
+	// ToUnit and ToRate are the MNB quote of the result currency.
+	ToUnit    int
+	ToRate    mnb.Double
+	Breakdown []calcLine
+}
+
+// calc evaluates the expression with the MNB rates of its day (or the last publication day before it).
+func calc(ctx context.Context, wsC mnb.MNBArfolyamService, expr string, places int32) (calcResult, error) {
+	now := time.Now()
+	e, err := parseCalc(expr, time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		return calcResult{}, fmt.Errorf("%q %w", expr, err)
+	}
+	res := calcResult{Expression: expr, To: e.To, Day: mnb.Date(e.Day)}
+	currencies := slices.Sorted(maps.Keys(e.Value))
+	need := slices.DeleteFunc(append(slices.Clone(currencies), e.To), func(c string) bool { return c == "HUF" })
+	day := mnb.DayRates{Day: res.Day}
+	if len(need) != 0 {
+		slices.Sort(need)
+		if day, err = wsC.GetExchangeRatesAt(ctx, e.Day, slices.Compact(need)...); err != nil {
+			return res, err
+		}
+	}
+	res.Day = day.Day
+	toRate, ok := day.Find(e.To)
+	if !ok {
+		return res, fmt.Errorf("%s on %s: %w", e.To, day.Day, mnb.ErrNoRate)
+	}
+	res.ToUnit, res.ToRate = toRate.Unit, toRate.Rate
+	res.Result = mnb.NewDouble(0, 0)
+	for _, c := range currencies {
+		r, ok := day.Find(c)
+		if !ok {
+			return res, fmt.Errorf("%s on %s: %w", c, day.Day, mnb.ErrNoRate)
+		}
+		line := calcLine{Currency: c, Amount: e.Value[c], Unit: r.Unit, Rate: r.Rate}
+		if line.CrossRate, err = day.CrossRate(c, e.To); err != nil {
+			return res, err
+		}
+		if line.Value, err = line.Amount.Mul(line.CrossRate); err != nil {
+			return res, err
+		}
+		if res.Result, err = res.Result.Add(line.Value); err != nil {
+			return res, err
+		}
+		line.CrossRate, line.Value = line.CrossRate.Round(calcRatePlaces), line.Value.Round(places)
+		res.Breakdown = append(res.Breakdown, line)
+	}
+	res.Result = res.Result.Round(places)
+	return res, nil
+}
+
+// calcRatePlaces is the number of decimal places of the printed cross rates.
+const calcRatePlaces = 6
+
+// printCalc prints the result as JSON for the json format, else as text.
+func printCalc(w io.Writer, res calcResult, outFormat string) error {
+	bw := bufio.NewWriter(w)
+	defer bw.Flush()
+	if outFormat == "json" {
+		enc := json.NewEncoder(bw)
+		enc.SetIndent("", "  ")
+		return enc.Encode(res)
+	}
+	fmt.Fprintf(bw, "%s = %s %s\n", res.Expression, outLocale.FormatDouble(res.Result), res.To)
+	fmt.Fprintf(bw, "MNB rates of %s:\n", outLocale.FormatDate(res.Day))
+	for _, l := range res.Breakdown {
+		fmt.Fprintf(bw, "  %s %s = %s %s", outLocale.FormatDouble(l.Amount), l.Currency, outLocale.FormatDouble(l.Value), res.To)
+		var rates []string
+		if l.Currency != "HUF" {
+			rates = append(rates, fmt.Sprintf("%d %s = %s HUF", l.Unit, l.Currency, outLocale.FormatDouble(l.Rate)))
+		}
+		if l.Currency != res.To && res.To != "HUF" {
+			rates = append(rates, fmt.Sprintf("1 %s = %s %s", l.Currency, outLocale.FormatDouble(l.CrossRate), res.To))
+		}
+		if len(rates) != 0 {
+			fmt.Fprintf(bw, "\t(%s)", strings.Join(rates, "; "))
+		}
+		bw.WriteByte('\n')
+	}
+	if res.To != "HUF" {
+		fmt.Fprintf(bw, "  %d %s = %s HUF\n", res.ToUnit, res.To, outLocale.FormatDouble(res.ToRate))
+	}
+	return bw.Flush()
+}
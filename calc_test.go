@@ -0,0 +1,140 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tgulacsi/mnbarf/mnb"
+)
+
+func TestParseCalc(t *testing.T) {
+	today := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		Expr string
+		// Value is the sorted "CUR=amount" list.
+		Value   string
+		To, Day string
+	}{
+		{Expr: "100 EUR + 2500 JPY - 30 USD in CHF @ 2024-05-02", Value: "EUR=100 JPY=2500 USD=-30", To: "CHF", Day: "2024-05-02"},
+		{Expr: "100 eur to usd", Value: "EUR=100", To: "USD"},
+		{Expr: "EUR 100 + 20 EUR", Value: "EUR=120"},
+		{Expr: "2 + 3 * 4", Value: "HUF=14"},
+		{Expr: "(2 + 3) * 4", Value: "HUF=20"},
+		{Expr: "2 * 3 EUR + 4 EUR / 2", Value: "EUR=8"},
+		{Expr: "2 * (3 EUR + 4 USD)", Value: "EUR=6 USD=8"},
+		{Expr: "1_000 EUR / 4 - 50 EUR", Value: "EUR=200"},
+		{Expr: "-10 EUR - -5 EUR", Value: "EUR=-5"},
+		{Expr: "-(10 EUR + 5 USD)", Value: "EUR=-10 USD=-5"},
+		{Expr: "10 EUR @ 2024.05.03. in HUF", Value: "EUR=10", Day: "2024-05-03"},
+	} {
+		e, err := parseCalc(tc.Expr, today)
+		if err != nil {
+			t.Errorf("%q: %+v", tc.Expr, err)
+			continue
+		}
+		var value []string
+		for _, c := range slices.Sorted(maps.Keys(e.Value)) {
+			value = append(value, c+"="+e.Value[c].String())
+		}
+		if got := strings.Join(value, " "); got != tc.Value {
+			t.Errorf("%q: got %s, wanted %s", tc.Expr, got, tc.Value)
+		}
+		if tc.To == "" {
+			tc.To = "HUF"
+		}
+		if e.To != tc.To {
+			t.Errorf("%q: got to %s, wanted %s", tc.Expr, e.To, tc.To)
+		}
+		if tc.Day == "" {
+			tc.Day = today.Format("2006-01-02")
+		}
+		if got := e.Day.Format("2006-01-02"); got != tc.Day {
+			t.Errorf("%q: got day %s, wanted %s", tc.Expr, got, tc.Day)
+		}
+	}
+}
+
+func TestParseCalcErrors(t *testing.T) {
+	for _, tc := range []struct {
+		Expr, Want string
+	}{
+		{"", "at 1: unexpected end"},
+		{"100 EUR +", "at 10: unexpected end"},
+		{"EUR", `at 4: number is expected instead of ""`},
+		{"100 EUR + 5", "at 12: cannot add a plain number to an amount"},
+		{"100 EUR * 2 USD", "at 16: amounts can be multiplied and divided by plain numbers only"},
+		{"100 / (2 EUR)", "at 14: amounts can be multiplied and divided by plain numbers only"},
+		{"100 EUR / 0", "at 12: division by zero"},
+		{"(100 EUR", "at 9: missing )"},
+		{"100 EUR in", "at 11: currency is expected"},
+		{"100 EUR in euro", "at 12: currency is expected"},
+		{"100 EUR + 3 EUR x", `at 17: unexpected "x"`},
+		{"100 EUR foo", `at 9: unexpected "foo"`},
+		{"100 EUR @ tomorrow", `"tomorrow" is not a date`},
+	} {
+		_, err := parseCalc(tc.Expr, time.Now())
+		if err == nil {
+			t.Errorf("%q: no error", tc.Expr)
+		} else if !strings.Contains(err.Error(), tc.Want) {
+			t.Errorf("%q: got %q, wanted %q", tc.Expr, err.Error(), tc.Want)
+		}
+	}
+}
+
+func TestCalc(t *testing.T) {
+	fake := &fakeMNB{Days: testRates(t,
+		"2024-05-02 EUR=391.00 USD=365.00 JPY/100=235.00",
+		"2024-05-03 EUR=390.50 USD=364.10 JPY/100=236.50",
+	)}
+	wsC := mnb.NewMNBArfolyamService(fake.serve(t).URL, nil, nil)
+	ctx := context.Background()
+
+	// Saturday: the rates of Friday
+	res, err := calc(ctx, wsC, "100 EUR + 2500 JPY in USD @ 2024-05-04", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// (100*390.50 + 25*236.50) / 364.10 = 44962.5 / 364.10
+	if got, want := res.Day.String(), "2024-05-03"; got != want {
+		t.Errorf("got day %s, wanted %s", got, want)
+	}
+	if got, want := res.Result.String(), "123.49"; got != want {
+		t.Errorf("got %s, wanted %s", got, want)
+	}
+	if got, want := res.ToRate.String(), "364.10"; got != want {
+		t.Errorf("got to rate %s, wanted %s", got, want)
+	}
+	var lines []string
+	for _, l := range res.Breakdown {
+		lines = append(lines, fmt.Sprintf("%s %d %s %s", l.Currency, l.Unit, l.CrossRate.String(), l.Value.String()))
+	}
+	if want := []string{"EUR 1 1.072508 107.25", "JPY 100 0.006495 16.24"}; !slices.Equal(lines, want) {
+		t.Errorf("got breakdown %q, wanted %q", lines, want)
+	}
+
+	// HUF only: no rates are needed
+	calls := fake.Calls("GetExchangeRates")
+	if res, err = calc(ctx, wsC, "1000 HUF + 250 HUF @ 2024-05-04", 2); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := res.Result.String(), "1250"; got != want {
+		t.Errorf("got %s, wanted %s", got, want)
+	}
+	if fake.Calls("GetExchangeRates") != calls {
+		t.Error("HUF only queried the rates")
+	}
+
+	if _, err = calc(ctx, wsC, "10 GBP @ 2024-05-03", 2); !errors.Is(err, mnb.ErrNoRate) {
+		t.Errorf("GBP: got %+v, wanted %v", err, mnb.ErrNoRate)
+	}
+}
@@ -0,0 +1,277 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/tgulacsi/mnbarf/mnb"
+)
+
+// budapest is the time zone of the MNB publications.
+var budapest = func() *time.Location {
+	loc, err := time.LoadLocation("Europe/Budapest")
+	if err != nil {
+		panic(err)
+	}
+	return loc
+}()
+
+// daemonAction is fired for each newly published day.
+type daemonAction struct {
+	Name string
+	Do   func(ctx context.Context, day mnb.DayRates) error
+}
+
+// daemon polls the current exchange rates when MNB publishes them
+// (on the business days of the Calendar around noon, Budapest time), till a new day appears,
+// then persists the rates and fires the actions and the hooks.
+// The day is recorded as the last seen only when all the actions succeeded;
+// till then, the next polls retry the failed actions.
+// With each poll it checks the base rate, too, and fires the hooks on a new publication.
+type daemon struct {
+	wsC mnb.MNBArfolyamService
+	wsR mnb.MNBAlapkamatService
+	// PublishAt is the time of the day (in Budapest) the polling starts at.
+	PublishAt time.Duration
+	// PollFor is how long the polling lasts after PublishAt, if no new rates appear.
+	PollFor time.Duration
+	// PollInterval is the wait between the polls.
+	PollInterval time.Duration
+	// MaxBackoff is the maximum wait after errors.
+	MaxBackoff time.Duration
+	// StateDir stores the received rates and the last day.
+	StateDir string
+	Actions  []daemonAction
+	Hooks    *hookDispatcher
+	// Calendar tells the business days MNB publishes on.
+	Calendar *mnb.Calendar
+
+	last, lastBase mnb.Date
+	// pending is the new day with failed actions, done marks its succeeded actions.
+	pending mnb.Date
+	done    []bool
+}
+
+func (d *daemon) statePath() string     { return filepath.Join(d.StateDir, "last") }
+func (d *daemon) baseStatePath() string { return filepath.Join(d.StateDir, "last-baserate") }
+
+// loadState reads the last seen publication days.
+func (d *daemon) loadState() error {
+	for fn, day := range map[string]*mnb.Date{d.statePath(): &d.last, d.baseStatePath(): &d.lastBase} {
+		b, err := os.ReadFile(fn)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return err
+		}
+		if err = day.UnmarshalText(bytes.TrimSpace(b)); err != nil {
+			return fmt.Errorf("%s: %w", fn, err)
+		}
+	}
+	return nil
+}
+
+// persist writes the rates into StateDir/rates/<day>.json.
+func (d *daemon) persist(day mnb.DayRates) error {
+	dir := filepath.Join(d.StateDir, "rates")
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return err
+	}
+	b, err := json.Marshal(day)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(dir, day.Day.String()+".json"), b)
+}
+
+func writeFileAtomic(fn string, b []byte) error {
+	fh, err := os.CreateTemp(filepath.Dir(fn), ".tmp-"+filepath.Base(fn)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(fh.Name())
+	if _, err = fh.Write(b); err != nil {
+		fh.Close()
+		return err
+	}
+	if err = fh.Close(); err != nil {
+		return err
+	}
+	return os.Rename(fh.Name(), fn)
+}
+
+// publicationWindow returns the polling window of the day of t.
+func (d *daemon) publicationWindow(t time.Time) (start, end time.Time) {
+	t = t.In(budapest)
+	start = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, budapest).Add(d.PublishAt)
+	return start, start.Add(d.PollFor)
+}
+
+// nextWait returns how long to wait before the next poll:
+// PollInterval inside the publication window if today's rates are missing,
+// else till the start of the next window.
+func (d *daemon) nextWait(now time.Time) time.Duration {
+	now = now.In(budapest)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if d.Calendar.IsBusinessDay(now) && time.Time(d.last).Before(today) {
+		start, end := d.publicationWindow(now)
+		if now.Before(start) {
+			return start.Sub(now)
+		}
+		if now.Before(end) {
+			return d.PollInterval
+		}
+	}
+	next := d.Calendar.NextBusinessDay(now)
+	start, _ := d.publicationWindow(time.Date(next.Year(), next.Month(), next.Day(), 12, 0, 0, 0, budapest))
+	return start.Sub(now)
+}
+
+// Poll asks for the current rates once, and if they're new, persists them and fires the actions.
+// If some actions fail, the day stays pending: the next Poll retries only the failed actions,
+// and records the day as the last seen when all of them succeeded.
+func (d *daemon) Poll(ctx context.Context) error {
+	if err := d.pollBaseRate(ctx); err != nil {
+		logger.Error("poll base rate", "error", err)
+	}
+	day, err := d.wsC.GetCurrentExchangeRates(ctx)
+	if err != nil {
+		return err
+	}
+	if !time.Time(day.Day).After(time.Time(d.last)) {
+		logger.Debug("no new rates", "day", day.Day, "last", d.last)
+		return nil
+	}
+	first := !time.Time(day.Day).Equal(time.Time(d.pending))
+	if first {
+		logger.Info("new rates", "day", day.Day, "last", d.last)
+		if err := d.persist(day); err != nil {
+			return err
+		}
+		d.pending, d.done = day.Day, make([]bool, len(d.Actions))
+		d.Calendar.Set(time.Time(day.Day), true)
+	} else {
+		logger.Info("retry actions", "day", day.Day)
+	}
+	var errs []error
+	for i, a := range d.Actions {
+		if d.done[i] {
+			continue
+		}
+		// the actions may modify the rates (printDayRates appends HUF)
+		day := mnb.DayRates{Day: day.Day, Rates: slices.Clone(day.Rates)}
+		if err := a.Do(ctx, day); err != nil {
+			logger.Error("action", "name", a.Name, "day", day.Day, "error", err)
+			errs = append(errs, fmt.Errorf("%s: %w", a.Name, err))
+			continue
+		}
+		d.done[i] = true
+	}
+	if first {
+		// the hooks retry and keep the failed deliveries in the dead letter file themselves
+		if err := d.Hooks.Fire(ctx, hookEvent{Event: "rates", Detected: time.Now(), Rates: &day}); err != nil {
+			logger.Error("hooks", "day", day.Day, "error", err)
+		}
+	}
+	if len(errs) != 0 {
+		return errors.Join(errs...)
+	}
+	if err := writeFileAtomic(d.statePath(), []byte(day.Day.String()+"\n")); err != nil {
+		return err
+	}
+	d.last, d.pending, d.done = day.Day, mnb.Date{}, nil
+	return nil
+}
+
+// pollBaseRate asks for the current base rate, and if it's a new publication, fires the hooks.
+// The publication is recorded as the last seen only when the hooks succeeded, else the next poll fires them again.
+func (d *daemon) pollBaseRate(ctx context.Context) error {
+	if d.Hooks == nil || len(d.Hooks.Hooks) == 0 {
+		return nil
+	}
+	rate, err := d.wsR.GetCurrentCentralBankBaseRate(ctx)
+	if err != nil {
+		return err
+	}
+	if !time.Time(rate.Publication).After(time.Time(d.lastBase)) {
+		return nil
+	}
+	logger.Info("new base rate", "publication", rate.Publication, "rate", rate.Rate, "last", d.lastBase)
+	if err := d.Hooks.Fire(ctx, hookEvent{Event: "baserate", Detected: time.Now(), BaseRate: &rate}); err != nil {
+		return err
+	}
+	if err := writeFileAtomic(d.baseStatePath(), []byte(rate.Publication.String()+"\n")); err != nil {
+		return err
+	}
+	d.lastBase = rate.Publication
+	return nil
+}
+
+// Run polls till the context is canceled, backing off on errors.
+func (d *daemon) Run(ctx context.Context) error {
+	if err := os.MkdirAll(d.StateDir, 0750); err != nil {
+		return err
+	}
+	if err := d.loadState(); err != nil {
+		return err
+	}
+	var backoff time.Duration
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("daemon stopped", "last", d.last)
+			return nil
+		case <-timer.C:
+		}
+		wait := d.PollInterval
+		if err := d.Poll(ctx); err != nil && ctx.Err() == nil {
+			backoff = min(max(2*backoff, time.Minute), d.MaxBackoff)
+			wait = backoff
+			logger.Error("poll", "error", err, "retry", wait)
+		} else {
+			backoff = 0
+			wait = d.nextWait(time.Now())
+			logger.Info("waiting", "last", d.last, "next", time.Now().Add(wait).In(budapest).Format(time.DateTime))
+		}
+		timer.Reset(wait)
+	}
+}
+
+// fileAction writes the new day's rates in the format into dir.
+func fileAction(dir, format string) daemonAction {
+	ext := format
+	switch {
+	case strings.HasPrefix(format, "@"):
+		ext = strings.TrimPrefix(filepath.Ext(format), ".")
+	case format == "markdown":
+		ext = "md"
+	case !slices.Contains([]string{"csv", "json", "sql", "html", "md"}, format):
+		ext = "txt"
+	}
+	return daemonAction{Name: "write " + dir, Do: func(ctx context.Context, day mnb.DayRates) error {
+		var buf bytes.Buffer
+		if err := printDayRates(&buf, []mnb.DayRates{day}, format); err != nil {
+			return err
+		}
+		if err := os.MkdirAll(dir, 0750); err != nil {
+			return err
+		}
+		return writeFileAtomic(filepath.Join(dir, day.Day.String()+"."+ext), buf.Bytes())
+	}}
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tgulacsi/mnbarf/mnb"
+)
+
+func TestDaemonPollRetriesFailedActions(t *testing.T) {
+	ctx := context.Background()
+	fake := fakeMNB{Days: testRates(t, "2024-05-03 EUR=390.50 USD=364.10")}
+	srv := fake.serve(t)
+	d := daemon{
+		wsC:      mnb.NewMNBArfolyamService(srv.URL, nil, nil),
+		wsR:      mnb.NewMNBAlapkamatService(srv.URL, nil, nil),
+		StateDir: t.TempDir(),
+		Calendar: mnb.NewCalendar(),
+	}
+	var okCalls, failCalls int
+	failing := true
+	d.Actions = []daemonAction{
+		{Name: "ok", Do: func(context.Context, mnb.DayRates) error { okCalls++; return nil }},
+		{Name: "fail", Do: func(context.Context, mnb.DayRates) error {
+			failCalls++
+			if failing {
+				return errors.New("db is down")
+			}
+			return nil
+		}},
+	}
+
+	if err := d.Poll(ctx); err == nil {
+		t.Fatal("wanted the error of the failed action")
+	}
+	if _, err := os.Stat(filepath.Join(d.StateDir, "last")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("the day is recorded as the last seen after a failed action (%v)", err)
+	}
+	if !time.Time(d.last).IsZero() {
+		t.Errorf("last=%s after a failed action", d.last)
+	}
+
+	failing = false
+	if err := d.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if okCalls != 1 || failCalls != 2 {
+		t.Errorf("got ok=%d fail=%d calls, wanted 1 and 2", okCalls, failCalls)
+	}
+	b, err := os.ReadFile(filepath.Join(d.StateDir, "last"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(b); got != "2024-05-03\n" {
+		t.Errorf("last state is %q", got)
+	}
+
+	// nothing new: no more actions
+	if err := d.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if okCalls != 1 || failCalls != 2 {
+		t.Errorf("got ok=%d fail=%d calls after an old day, wanted 1 and 2", okCalls, failCalls)
+	}
+}
@@ -0,0 +1,10 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build godror
+
+package main
+
+// The Oracle driver needs cgo and the Oracle client libraries, so it is only built with -tags=godror.
+import _ "github.com/godror/godror"
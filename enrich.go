@@ -0,0 +1,271 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/tgulacsi/mnbarf/mnb"
+)
+
+// enricher appends the MNB rate, its day and the converted amount to each record of a CSV or NDJSON stream.
+type enricher struct {
+	DateCol, AmountCol, CurrencyCol string
+	// To is the target currency.
+	To     string
+	Places int32
+}
+
+// enrichRatePlaces is the number of decimal places of the printed rates.
+const enrichRatePlaces = 6
+
+// enrichRecord is an input record, with its fields needed for the conversion.
+type enrichRecord struct {
+	Line     int
+	Day      time.Time
+	Currency string
+	Amount   mnb.Double
+	// Fields of a CSV record, or Object of an NDJSON line.
+	Fields []string
+	Object []byte
+}
+
+// columns returns the names of the appended columns.
+func (e enricher) columns() []string {
+	return []string{"mnb_rate", "mnb_rate_day", "amount_" + strings.ToLower(e.To)}
+}
+
+// Enrich reads all the records of r (CSV or NDJSON, by inFormat, or detected by the first character for "auto"),
+// fetches the rates of all their days and currencies with one GetExchangeRates call,
+// and writes the enriched records to w, in the input format.
+func (e enricher) Enrich(ctx context.Context, wsC mnb.MNBArfolyamService, r io.Reader, w io.Writer, inFormat string) error {
+	br := bufio.NewReader(r)
+	if inFormat == "auto" {
+		inFormat = "csv"
+		for n := 1; ; n++ {
+			b, _ := br.Peek(n)
+			if len(b) < n {
+				break
+			}
+			if c := b[n-1]; c == ' ' || c == '\t' || c == '\r' || c == '\n' {
+				continue
+			} else if c == '{' {
+				inFormat = "ndjson"
+			}
+			break
+		}
+	}
+	var header []string
+	var recs []enrichRecord
+	var comma rune
+	var err error
+	switch inFormat {
+	case "csv":
+		header, recs, comma, err = e.readCSV(br)
+	case "ndjson":
+		recs, err = e.readNDJSON(br)
+	default:
+		return fmt.Errorf("unknown input format %q (auto, csv or ndjson is accepted)", inFormat)
+	}
+	if err != nil {
+		return err
+	}
+	if len(recs) == 0 && header == nil {
+		return nil
+	}
+
+	// all the days and currencies with one call
+	currencies := []string{e.To}
+	var begin, end time.Time
+	for i, rec := range recs {
+		if !slices.Contains(currencies, rec.Currency) {
+			currencies = append(currencies, rec.Currency)
+		}
+		if i == 0 || rec.Day.Before(begin) {
+			begin = rec.Day
+		}
+		if i == 0 || rec.Day.After(end) {
+			end = rec.Day
+		}
+	}
+	var h mnb.History
+	if len(recs) != 0 {
+		if h, err = wsC.GetHistory(ctx, begin, end, currencies...); err != nil {
+			return err
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	defer bw.Flush()
+	var cw *csv.Writer
+	if header != nil {
+		cw = csv.NewWriter(bw)
+		cw.Comma = comma
+		if err := cw.Write(append(header, e.columns()...)); err != nil {
+			return err
+		}
+	}
+	for _, rec := range recs {
+		value, rate, day, err := h.Convert(rec.Day, rec.Amount, rec.Currency, e.To)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", rec.Line, err)
+		}
+		// the cross rates may have many decimals, the value is computed with the exact one
+		value, rate = value.Round(e.Places), rate.Round(enrichRatePlaces)
+		if cw != nil {
+			if err := cw.Write(append(rec.Fields,
+				outLocale.FormatDouble(rate), outLocale.FormatDate(day), outLocale.FormatDouble(value),
+			)); err != nil {
+				return err
+			}
+			continue
+		}
+		obj, err := appendObject(rec.Object, e.columns(), []any{rate, day, value})
+		if err != nil {
+			return err
+		}
+		bw.Write(obj)
+		if err := bw.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+	if cw != nil {
+		if cw.Flush(); cw.Error() != nil {
+			return cw.Error()
+		}
+	}
+	return bw.Flush()
+}
+
+// appendObject appends the fields to the JSON object, keeping its order and formatting.
+func appendObject(obj []byte, names []string, values []any) ([]byte, error) {
+	obj = bytes.TrimSpace(obj)
+	buf := bytes.NewBuffer(slices.Clone(obj[:len(obj)-1]))
+	sep := ","
+	if len(bytes.TrimSpace(obj[1:len(obj)-1])) == 0 {
+		sep = ""
+	}
+	for i, name := range names {
+		b, err := json.Marshal(values[i])
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(buf, "%s%q:%s", sep, name, b)
+		sep = ","
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func (e enricher) readCSV(r io.Reader) (header []string, recs []enrichRecord, comma rune, err error) {
+	cr := csvReader(r)
+	cr.ReuseRecord = false
+	if header, err = cr.Read(); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = nil
+		}
+		return nil, nil, cr.Comma, err
+	}
+	cols := csvColumns(header)
+	idx := make([]int, 3)
+	for i, c := range []string{e.DateCol, e.AmountCol, e.CurrencyCol} {
+		var ok bool
+		if idx[i], ok = cols[strings.ToLower(c)]; !ok {
+			return header, nil, cr.Comma, fmt.Errorf("no %q column in %q", c, header)
+		}
+	}
+	for {
+		fields, err := cr.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return header, recs, cr.Comma, nil
+			}
+			return header, recs, cr.Comma, err
+		}
+		line, _ := cr.FieldPos(0)
+		get := func(i int) string {
+			if i < len(fields) {
+				return strings.TrimSpace(fields[i])
+			}
+			return ""
+		}
+		rec, err := e.record(line, get(idx[0]), get(idx[1]), get(idx[2]))
+		if err != nil {
+			return header, recs, cr.Comma, err
+		}
+		rec.Fields = fields
+		recs = append(recs, rec)
+	}
+}
+
+func (e enricher) readNDJSON(r io.Reader) ([]enrichRecord, error) {
+	var recs []enrichRecord
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		b := bytes.TrimSpace(scanner.Bytes())
+		if len(b) == 0 {
+			continue
+		}
+		if b[0] != '{' {
+			return recs, fmt.Errorf("line %d: not a JSON object", line)
+		}
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(b, &obj); err != nil {
+			return recs, fmt.Errorf("line %d: %w", line, err)
+		}
+		get := func(k string) string {
+			v := obj[k]
+			var s string
+			if json.Unmarshal(v, &s) == nil {
+				return strings.TrimSpace(s)
+			}
+			return string(bytes.TrimSpace(v))
+		}
+		rec, err := e.record(line, get(e.DateCol), get(e.AmountCol), get(e.CurrencyCol))
+		if err != nil {
+			return recs, err
+		}
+		rec.Object = slices.Clone(b)
+		recs = append(recs, rec)
+	}
+	return recs, scanner.Err()
+}
+
+// record parses the fields of the record.
+func (e enricher) record(line int, dayS, amountS, currency string) (enrichRecord, error) {
+	rec := enrichRecord{Line: line, Currency: strings.ToUpper(currency)}
+	if rec.Currency == "" {
+		return rec, fmt.Errorf("line %d: no %s", line, e.CurrencyCol)
+	}
+	var err error
+	if rec.Day, err = parseDay(dayS); err != nil {
+		return rec, fmt.Errorf("line %d: %s: %w", line, e.DateCol, err)
+	}
+	if err = rec.Amount.UnmarshalText([]byte(amountS)); err != nil {
+		return rec, fmt.Errorf("line %d: %s: %w", line, e.AmountCol, err)
+	}
+	return rec, nil
+}
+
+// parseDay parses the day in ISO (2006-01-02, or RFC 3339 timestamp) or Hungarian (2006.01.02.) format.
+func parseDay(s string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02", "2006.01.02.", "2006.01.02", "2006. 01. 02.", "2006/01/02", time.RFC3339} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q is not a date (2006-01-02)", s)
+}
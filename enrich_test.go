@@ -0,0 +1,112 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/tgulacsi/mnbarf/mnb"
+)
+
+func TestEnrich(t *testing.T) {
+	// the default, unlocalized format
+	defer func(old mnb.Locale) { outLocale = old }(outLocale)
+	outLocale = mnb.Locale{}
+
+	for _, tc := range []struct {
+		Name, In, Format, Want string
+		Calls                  int
+	}{
+		{Name: "comma", Format: "auto",
+			In: "date,amount,currency,note\n2024-05-03,100,EUR,a\n2024-05-04,10,jpy,b\n",
+			Want: "date,amount,currency,note,mnb_rate,mnb_rate_day,amount_huf\n" +
+				"2024-05-03,100,EUR,a,390.5,2024-05-03,39050\n" +
+				"2024-05-04,10,jpy,b,2.365,2024-05-03,23.65\n", Calls: 1},
+		{Name: "semicolon", Format: "csv",
+			In: "Currency;Date;Amount\nEUR;2024.05.02.;2,5\n",
+			Want: "Currency;Date;Amount;mnb_rate;mnb_rate_day;amount_huf\n" +
+				"EUR;2024.05.02.;2,5;392;2024-05-02;980\n", Calls: 1},
+		{Name: "tab", Format: "auto",
+			In: "date\tcurrency\tamount\n2024-05-03\tEUR\t1\n",
+			Want: "date\tcurrency\tamount\tmnb_rate\tmnb_rate_day\tamount_huf\n" +
+				"2024-05-03\tEUR\t1\t390.5\t2024-05-03\t390.5\n", Calls: 1},
+		{Name: "header only", Format: "auto",
+			In:   "date,amount,currency\n",
+			Want: "date,amount,currency,mnb_rate,mnb_rate_day,amount_huf\n"},
+		{Name: "ndjson", Format: "auto",
+			In: "\n  {\"date\": \"2024-05-03\", \"amount\": 100, \"currency\": \"EUR\", \"x\": [1]}\n\n" +
+				`{"currency":"JPY","amount":"1000","date":"2024-05-02T10:00:00+02:00"}` + "\n",
+			Want: `{"date": "2024-05-03", "amount": 100, "currency": "EUR", "x": [1],"mnb_rate":"390.5","mnb_rate_day":"2024-05-03","amount_huf":"39050"}` + "\n" +
+				`{"currency":"JPY","amount":"1000","date":"2024-05-02T10:00:00+02:00","mnb_rate":"2.351","mnb_rate_day":"2024-05-02","amount_huf":"2351"}` + "\n",
+			Calls: 1},
+		{Name: "empty", Format: "auto"},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			fake := fakeMNB{Days: testRates(t,
+				"2024-05-02 EUR=392.00 JPY/100=235.10",
+				"2024-05-03 EUR=390.50 JPY/100=236.50",
+			)}
+			srv := fake.serve(t)
+			e := enricher{DateCol: "date", AmountCol: "amount", CurrencyCol: "currency", To: "HUF", Places: 2}
+			var buf strings.Builder
+			if err := e.Enrich(context.Background(), mnb.NewMNBArfolyamService(srv.URL, nil, nil),
+				strings.NewReader(tc.In), &buf, tc.Format,
+			); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tc.Want {
+				t.Errorf("got\n%s\nwanted\n%s", got, tc.Want)
+			}
+			// all the days and currencies are fetched at once
+			if got := fake.Calls("GetExchangeRates"); got != tc.Calls {
+				t.Errorf("got %d GetExchangeRates calls, wanted %d", got, tc.Calls)
+			}
+		})
+	}
+}
+
+func TestEnrichErrors(t *testing.T) {
+	fake := fakeMNB{Days: testRates(t, "2024-05-03 EUR=390.50")}
+	srv := fake.serve(t)
+	wsC := mnb.NewMNBArfolyamService(srv.URL, nil, nil)
+	e := enricher{DateCol: "date", AmountCol: "amount", CurrencyCol: "currency", To: "HUF", Places: 2}
+	for _, tc := range []struct {
+		In, Format, Want string
+	}{
+		{In: "date,amount\n2024-05-03,1\n", Format: "csv", Want: `no "currency" column`},
+		{In: "date,amount,currency\n2024-05-03,x,EUR\n", Format: "csv", Want: "line 2: amount"},
+		{In: "date,amount,currency\n3 May,1,EUR\n", Format: "csv", Want: "line 2: date"},
+		{In: `{"date":"2024-05-03","amount":1,"currency":"EUR"}` + "\n[1]\n", Format: "ndjson", Want: "line 2: not a JSON object"},
+		{In: "{}\n", Format: "ndjson", Want: "line 1: no currency"},
+		{In: "", Format: "xml", Want: "unknown input format"},
+	} {
+		err := e.Enrich(context.Background(), wsC, strings.NewReader(tc.In), new(strings.Builder), tc.Format)
+		if err == nil || !strings.Contains(err.Error(), tc.Want) {
+			t.Errorf("%q: got %v, wanted %q", tc.In, err, tc.Want)
+		}
+	}
+	if got := fake.Calls("GetExchangeRates"); got != 0 {
+		t.Errorf("got %d GetExchangeRates calls for bad input", got)
+	}
+}
+
+func TestAppendObject(t *testing.T) {
+	for _, tc := range []struct{ In, Want string }{
+		{In: `{}`, Want: `{"a":1,"b":"x"}`},
+		{In: "{ \t}", Want: "{ \t" + `"a":1,"b":"x"}`},
+		{In: ` {"c":null} `, Want: `{"c":null,"a":1,"b":"x"}`},
+		{In: `{ "c": {} }`, Want: `{ "c": {} ,"a":1,"b":"x"}`},
+	} {
+		got, err := appendObject([]byte(tc.In), []string{"a", "b"}, []any{1, "x"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tc.Want {
+			t.Errorf("%q: got %s, wanted %s", tc.In, got, tc.Want)
+		}
+	}
+}
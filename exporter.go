@@ -0,0 +1,127 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/tgulacsi/mnbarf/mnb"
+)
+
+// exporter polls the current exchange rates and base rate, and publishes them as Prometheus gauges.
+type exporter struct {
+	wsC mnb.MNBArfolyamService
+	wsR mnb.MNBAlapkamatService
+
+	registry       *prometheus.Registry
+	rate           *prometheus.GaugeVec
+	baseRate       prometheus.Gauge
+	publication    *prometheus.GaugeVec
+	scrapeSuccess  *prometheus.GaugeVec
+	scrapeDuration *prometheus.GaugeVec
+	scrapeErrors   *prometheus.CounterVec
+	scrapeLast     *prometheus.GaugeVec
+}
+
+func newExporter(wsC mnb.MNBArfolyamService, wsR mnb.MNBAlapkamatService) *exporter {
+	e := exporter{
+		wsC: wsC, wsR: wsR,
+		registry: prometheus.NewRegistry(),
+		rate: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "mnb_exchange_rate",
+			Help: "The current MNB exchange rate of unit amount of the currency, in HUF.",
+		}, []string{"currency", "unit"}),
+		baseRate: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "mnb_base_rate",
+			Help: "The current MNB central bank base rate, in percent.",
+		}),
+		publication: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "mnb_publication_timestamp",
+			Help: "The publication day of the current rates, as Unix time.",
+		}, []string{"kind"}),
+		scrapeSuccess: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "mnb_scrape_success",
+			Help: "Whether the last call to MNB succeeded (1) or not (0).",
+		}, []string{"call"}),
+		scrapeDuration: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "mnb_scrape_duration_seconds",
+			Help: "The duration of the last call to MNB.",
+		}, []string{"call"}),
+		scrapeErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "mnb_scrape_errors_total",
+			Help: "The number of failed calls to MNB.",
+		}, []string{"call"}),
+		scrapeLast: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "mnb_scrape_timestamp_seconds",
+			Help: "The time of the last call to MNB, as Unix time.",
+		}, []string{"call"}),
+	}
+	e.registry.MustRegister(
+		e.rate, e.baseRate, e.publication,
+		e.scrapeSuccess, e.scrapeDuration, e.scrapeErrors, e.scrapeLast,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return &e
+}
+
+// observe records the health metrics of a call.
+func (e *exporter) observe(call string, start time.Time, err error) {
+	e.scrapeDuration.WithLabelValues(call).Set(time.Since(start).Seconds())
+	e.scrapeLast.WithLabelValues(call).Set(float64(start.Unix()))
+	if err != nil {
+		logger.Error(call, "error", err)
+		e.scrapeErrors.WithLabelValues(call).Inc()
+		e.scrapeSuccess.WithLabelValues(call).Set(0)
+		return
+	}
+	e.scrapeSuccess.WithLabelValues(call).Set(1)
+}
+
+// Update polls MNB once.
+func (e *exporter) Update(ctx context.Context) {
+	start := time.Now()
+	day, err := e.wsC.GetCurrentExchangeRates(ctx)
+	e.observe("GetCurrentExchangeRates", start, err)
+	if err == nil {
+		e.rate.Reset()
+		for _, r := range day.Rates {
+			e.rate.WithLabelValues(r.Currency, strconv.Itoa(r.Unit)).Set(r.Rate.Float64())
+		}
+		e.publication.WithLabelValues("exchange_rate").Set(float64(time.Time(day.Day).Unix()))
+	}
+
+	start = time.Now()
+	base, err := e.wsR.GetCurrentCentralBankBaseRate(ctx)
+	e.observe("GetCurrentCentralBankBaseRate", start, err)
+	if err == nil {
+		e.baseRate.Set(base.Rate.Float64())
+		e.publication.WithLabelValues("base_rate").Set(float64(time.Time(base.Publication).Unix()))
+	}
+}
+
+// Run polls MNB in every interval, till the context is canceled.
+func (e *exporter) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		e.Update(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *exporter) Handler() http.Handler {
+	return promhttp.HandlerFor(e.registry, promhttp.HandlerOpts{Registry: e.registry})
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/tgulacsi/mnbarf/mnb"
+)
+
+func TestExporterUpdate(t *testing.T) {
+	fake := fakeMNB{
+		Days:      testRates(t, "2024-05-02 EUR=392.00", "2024-05-03 EUR=390.50 JPY/100=236.50"),
+		BaseRates: []mnb.MNBBaseRate{{Rate: testRate(t, "HUF", 1, "7.75").Rate}},
+	}
+	if err := fake.BaseRates[0].Publication.UnmarshalText([]byte("2024-04-24")); err != nil {
+		t.Fatal(err)
+	}
+	srv := fake.serve(t)
+	e := newExporter(mnb.NewMNBArfolyamService(srv.URL, nil, nil), mnb.NewMNBAlapkamatService(srv.URL, nil, nil))
+
+	e.Update(context.Background())
+	const want = `# HELP mnb_base_rate The current MNB central bank base rate, in percent.
+# TYPE mnb_base_rate gauge
+mnb_base_rate 7.75
+# HELP mnb_exchange_rate The current MNB exchange rate of unit amount of the currency, in HUF.
+# TYPE mnb_exchange_rate gauge
+mnb_exchange_rate{currency="EUR",unit="1"} 390.5
+mnb_exchange_rate{currency="JPY",unit="100"} 236.5
+# HELP mnb_publication_timestamp The publication day of the current rates, as Unix time.
+# TYPE mnb_publication_timestamp gauge
+mnb_publication_timestamp{kind="base_rate"} 1.7139168e+09
+mnb_publication_timestamp{kind="exchange_rate"} 1.7146944e+09
+`
+	if err := testutil.GatherAndCompare(e.registry, strings.NewReader(want),
+		"mnb_exchange_rate", "mnb_base_rate", "mnb_publication_timestamp"); err != nil {
+		t.Error(err)
+	}
+	for _, call := range []string{"GetCurrentExchangeRates", "GetCurrentCentralBankBaseRate"} {
+		if got := testutil.ToFloat64(e.scrapeSuccess.WithLabelValues(call)); got != 1 {
+			t.Errorf("%s: got success=%v", call, got)
+		}
+		if got := testutil.ToFloat64(e.scrapeErrors.WithLabelValues(call)); got != 0 {
+			t.Errorf("%s: got errors=%v", call, got)
+		}
+		if got := testutil.ToFloat64(e.scrapeLast.WithLabelValues(call)); got == 0 {
+			t.Errorf("%s: no timestamp", call)
+		}
+	}
+
+	// a failed poll keeps the last rates
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	e.Update(ctx)
+	for _, call := range []string{"GetCurrentExchangeRates", "GetCurrentCentralBankBaseRate"} {
+		if got := testutil.ToFloat64(e.scrapeSuccess.WithLabelValues(call)); got != 0 {
+			t.Errorf("%s: got success=%v after a failure", call, got)
+		}
+		if got := testutil.ToFloat64(e.scrapeErrors.WithLabelValues(call)); got != 1 {
+			t.Errorf("%s: got errors=%v after a failure", call, got)
+		}
+	}
+	if got := testutil.ToFloat64(e.rate.WithLabelValues("EUR", "1")); got != 390.5 {
+		t.Errorf("got EUR=%v after a failure", got)
+	}
+	problems, err := testutil.GatherAndLint(e.registry, "mnb_exchange_rate", "mnb_base_rate", "mnb_publication_timestamp",
+		"mnb_scrape_success", "mnb_scrape_duration_seconds", "mnb_scrape_errors_total", "mnb_scrape_timestamp_seconds")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range problems {
+		t.Errorf("lint %s: %s", p.Metric, p.Text)
+	}
+}
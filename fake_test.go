@@ -0,0 +1,191 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/tgulacsi/mnbarf/mnb"
+)
+
+// fakeMNB is a fake of the MNB SOAP services, answering from Days and BaseRates
+// with the same filtering of the days and currencies as the real one.
+type fakeMNB struct {
+	Days      []mnb.DayRates
+	BaseRates []mnb.MNBBaseRate
+
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+// Calls returns the number of calls of the action (such as GetExchangeRates).
+func (f *fakeMNB) Calls(action string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[action]
+}
+
+var rxSOAPParam = regexp.MustCompile(`<web:(startDate|endDate|currencyNames)>([^<]*)</web:`)
+
+// serve starts the fake server, closed at the end of the test.
+func (f *fakeMNB) serve(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		action := r.Header.Get("SOAPAction")
+		name := action[strings.LastIndexByte(action, '/')+1:]
+		f.mu.Lock()
+		if f.calls == nil {
+			f.calls = make(map[string]int)
+		}
+		f.calls[name]++
+		f.mu.Unlock()
+
+		b, _ := io.ReadAll(r.Body)
+		var start, end string
+		var currencies []string
+		for _, m := range rxSOAPParam.FindAllStringSubmatch(string(b), -1) {
+			switch m[1] {
+			case "startDate":
+				start = m[2]
+			case "endDate":
+				end = m[2]
+			case "currencyNames":
+				currencies = append(currencies, strings.Split(m[2], ",")...)
+			}
+		}
+		inPeriod := func(d mnb.Date) bool {
+			s := d.String()
+			return (start == "" || s >= start) && (end == "" || s <= end)
+		}
+		var buf strings.Builder
+		writeDay := func(d mnb.DayRates, all bool) {
+			fmt.Fprintf(&buf, `<Day date="%s">`, d.Day)
+			for _, r := range d.Rates {
+				if all || slices.Contains(currencies, r.Currency) {
+					fmt.Fprintf(&buf, `<Rate unit="%d" curr="%s">%s</Rate>`,
+						r.Unit, r.Currency, strings.ReplaceAll(r.Rate.String(), ".", ","))
+				}
+			}
+			buf.WriteString(`</Day>`)
+		}
+		switch name {
+		case "GetCurrentExchangeRates":
+			buf.WriteString(`<MNBCurrentExchangeRates>`)
+			if i := f.last(); i >= 0 {
+				writeDay(f.Days[i], true)
+			}
+			buf.WriteString(`</MNBCurrentExchangeRates>`)
+		case "GetExchangeRates":
+			buf.WriteString(`<MNBExchangeRates>`)
+			for _, d := range f.Days {
+				if inPeriod(d.Day) {
+					writeDay(d, false)
+				}
+			}
+			buf.WriteString(`</MNBExchangeRates>`)
+		case "GetCurrentCentralBankBaseRate":
+			buf.WriteString(`<MNBCurrentCentralBankBaseRate>`)
+			if n := len(f.BaseRates); n != 0 {
+				r := f.BaseRates[n-1]
+				fmt.Fprintf(&buf, `<BaseRate publicationDate="%s">%s</BaseRate>`,
+					r.Publication, strings.ReplaceAll(r.Rate.String(), ".", ","))
+			}
+			buf.WriteString(`</MNBCurrentCentralBankBaseRate>`)
+		case "GetCentralBankBaseRate":
+			buf.WriteString(`<MNBCentralBankBaseRates>`)
+			for _, r := range f.BaseRates {
+				if inPeriod(r.Publication) {
+					fmt.Fprintf(&buf, `<BaseRate publicationDate="%s">%s</BaseRate>`,
+						r.Publication, strings.ReplaceAll(r.Rate.String(), ".", ","))
+				}
+			}
+			buf.WriteString(`</MNBCentralBankBaseRates>`)
+		case "GetCurrencies":
+			buf.WriteString(`<MNBCurrencies><Currencies><Curr>HUF</Curr>`)
+			if i := f.last(); i >= 0 {
+				for _, r := range f.Days[i].Rates {
+					fmt.Fprintf(&buf, `<Curr>%s</Curr>`, r.Currency)
+				}
+			}
+			buf.WriteString(`</Currencies></MNBCurrencies>`)
+		default:
+			http.Error(w, name+" is not faked", http.StatusNotImplemented)
+			return
+		}
+		w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+		fmt.Fprintf(w, `<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/"><s:Body><%sResponse xmlns="http://www.mnb.hu/webservices/"><%sResult><![CDATA[%s]]></%sResult></%sResponse></s:Body></s:Envelope>`,
+			name, name, buf.String(), name, name)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// last returns the index of the last day, or -1.
+func (f *fakeMNB) last() int {
+	last := -1
+	for i, d := range f.Days {
+		if last < 0 || d.Day.String() > f.Days[last].Day.String() {
+			last = i
+		}
+	}
+	return last
+}
+
+// testRates returns the days of the fake, from "day CUR=rate CUR/unit=rate ..." lines.
+func testRates(t *testing.T, lines ...string) []mnb.DayRates {
+	t.Helper()
+	days := make([]mnb.DayRates, 0, len(lines))
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		day := testDay(t, fields[0])
+		for _, f := range fields[1:] {
+			k, v, _ := strings.Cut(f, "=")
+			curr, unitS, _ := strings.Cut(k, "/")
+			unit := 1
+			if unitS != "" {
+				fmt.Sscan(unitS, &unit)
+			}
+			day.Rates = append(day.Rates, testRate(t, curr, unit, v))
+		}
+		days = append(days, day)
+	}
+	return days
+}
+
+// testDay returns the day with the rates.
+func testDay(t *testing.T, day string, rates ...mnb.Rate) mnb.DayRates {
+	t.Helper()
+	d := mnb.DayRates{Rates: rates}
+	if err := d.Day.UnmarshalText([]byte(day)); err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+// testRate returns the rate of unit of the currency.
+func testRate(t *testing.T, currency string, unit int, rate string) mnb.Rate {
+	t.Helper()
+	r := mnb.Rate{Currency: currency, Unit: unit}
+	if err := r.Rate.UnmarshalText([]byte(rate)); err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+// checkDouble reports an error if got is not numerically equal to want.
+func checkDouble(t *testing.T, name string, got mnb.Double, want string) {
+	t.Helper()
+	if got.Decimal == nil || got.Cmp(testRate(t, "", 1, want).Rate.Decimal) != 0 {
+		t.Errorf("%s: got %v, wanted %s", name, got, want)
+	}
+}
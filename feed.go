@@ -0,0 +1,228 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tgulacsi/mnbarf/mnb"
+)
+
+// atomFeed is an Atom (RFC 4287) feed.
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated time.Time   `xml:"updated"`
+	Author  atomPerson  `xml:"author"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomLink struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+type atomEntry struct {
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated time.Time   `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Content atomContent `xml:"content"`
+}
+
+type atomContent struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+// feedDays is the default number of days in the rates feed, feedYears is the default years in the base rate feed;
+// feedMaxDays and feedMaxYears are the most that can be asked for.
+const (
+	feedDays     = 30
+	feedYears    = 5
+	feedMaxDays  = 366
+	feedMaxYears = 30
+)
+
+// feedTime returns the time of the publication day, in Budapest.
+func feedTime(d mnb.Date) time.Time {
+	t := time.Time(d)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, budapest)
+}
+
+// requestBase returns the scheme://host of the request.
+func requestBase(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if p := r.Header.Get("X-Forwarded-Proto"); p != "" {
+		scheme = p
+	}
+	return scheme + "://" + r.Host
+}
+
+func writeAtom(w http.ResponseWriter, r *http.Request, feed atomFeed, err error) {
+	if err != nil {
+		writeJSON(w, r, nil, err)
+		return
+	}
+	feed.Updated = time.Now()
+	if len(feed.Entries) != 0 {
+		feed.Updated = feed.Entries[0].Updated
+	}
+	w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+	_, _ = w.Write([]byte(xml.Header))
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(feed); err != nil {
+		logger.Info("encode", "url", r.URL.String(), "error", err)
+	}
+}
+
+// queryInt returns the positive integer query parameter (at most max), or the default.
+func queryInt(r *http.Request, name string, def, max int) (int, error) {
+	s := r.URL.Query().Get(name)
+	if s == "" {
+		return def, nil
+	}
+	i, err := strconv.Atoi(s)
+	if err != nil || i <= 0 {
+		return def, fmt.Errorf("%w: %s=%q: positive integer is needed", errBadRequest, name, s)
+	}
+	if i > max {
+		return def, fmt.Errorf("%w: %s=%d: at most %d is allowed", errBadRequest, name, i, max)
+	}
+	return i, nil
+}
+
+// handleRatesFeed serves an Atom feed with an entry for each publication day of the last days
+// (30 by default, at most 366), with the rates of the given currencies (all by default).
+func (s *apiServer) handleRatesFeed(w http.ResponseWriter, r *http.Request) {
+	base := requestBase(r)
+	feed := atomFeed{
+		ID:     "urn:mnbarf:feed:rates",
+		Title:  "MNB exchange rates",
+		Author: atomPerson{Name: "Magyar Nemzeti Bank"},
+		Links:  []atomLink{{Rel: "self", Type: "application/atom+xml", Href: base + r.URL.RequestURI()}},
+	}
+	n, err := queryInt(r, "days", feedDays, feedMaxDays)
+	if err != nil {
+		writeAtom(w, r, feed, err)
+		return
+	}
+	currencies := queryCurrencies(r)
+	if len(currencies) == 0 {
+		current, err := s.wsC.GetCurrentExchangeRates(r.Context())
+		if err != nil {
+			writeAtom(w, r, feed, err)
+			return
+		}
+		for _, rate := range current.Rates {
+			currencies = append(currencies, rate.Currency)
+		}
+	}
+	end := time.Now()
+	// a week more, for the changes of the first day
+	days, err := s.wsC.GetExchangeRates(r.Context(), end.AddDate(0, 0, -n-7), end, currencies...)
+	if err != nil {
+		writeAtom(w, r, feed, err)
+		return
+	}
+	slices.SortFunc(days, func(a, b mnb.DayRates) int { return time.Time(b.Day).Compare(time.Time(a.Day)) })
+	cutoff := end.AddDate(0, 0, -n)
+	for i, day := range days {
+		if time.Time(day.Day).Before(cutoff) {
+			break
+		}
+		var prev mnb.DayRates
+		if i+1 < len(days) {
+			prev = days[i+1]
+		}
+		q := url.Values{"from": {day.Day.String()}, "to": {day.Day.String()}, "currency": {strings.Join(currencies, ",")}}
+		feed.Entries = append(feed.Entries, atomEntry{
+			ID:      "urn:mnbarf:rates:" + day.Day.String(),
+			Title:   "MNB exchange rates of " + day.Day.String(),
+			Updated: feedTime(day.Day),
+			Links:   []atomLink{{Rel: "alternate", Type: "application/json", Href: base + "/rates?" + q.Encode()}},
+			Content: atomContent{Type: "html", Body: ratesFeedHTML(day, prev)},
+		})
+	}
+	writeAtom(w, r, feed, nil)
+}
+
+// ratesFeedHTML returns the rates of the day as an HTML table, with the change since prev.
+func ratesFeedHTML(day, prev mnb.DayRates) string {
+	var buf strings.Builder
+	buf.WriteString("<table><tr><th>Currency</th><th>Unit</th><th>Rate</th><th>Change</th></tr>")
+	for _, r := range day.Rates {
+		var change string
+		if p, ok := prev.Find(r.Currency); ok && p.Unit == r.Unit {
+			if pct, err := mnb.PercentChange(p.Rate, r.Rate); err == nil {
+				change = outLocale.FormatDouble(pct.Round(2)) + "%"
+			}
+		}
+		fmt.Fprintf(&buf, "<tr><td>%s</td><td>%d</td><td>%s</td><td>%s</td></tr>",
+			html.EscapeString(r.Currency), r.Unit, outLocale.FormatDouble(r.Rate), change)
+	}
+	buf.WriteString("</table>")
+	return buf.String()
+}
+
+// handleBaseRateFeed serves an Atom feed with an entry for each base rate change
+// of the last years (5 by default, at most 30).
+func (s *apiServer) handleBaseRateFeed(w http.ResponseWriter, r *http.Request) {
+	base := requestBase(r)
+	feed := atomFeed{
+		ID:     "urn:mnbarf:feed:baserate",
+		Title:  "MNB central bank base rate",
+		Author: atomPerson{Name: "Magyar Nemzeti Bank"},
+		Links:  []atomLink{{Rel: "self", Type: "application/atom+xml", Href: base + r.URL.RequestURI()}},
+	}
+	n, err := queryInt(r, "years", feedYears, feedMaxYears)
+	if err != nil {
+		writeAtom(w, r, feed, err)
+		return
+	}
+	end := time.Now()
+	rates, err := s.wsR.GetCentralBankBaseRate(r.Context(), end.AddDate(-n, 0, 0), end)
+	if err != nil {
+		writeAtom(w, r, feed, err)
+		return
+	}
+	slices.SortFunc(rates, func(a, b mnb.MNBBaseRate) int { return time.Time(b.Publication).Compare(time.Time(a.Publication)) })
+	for i, rate := range rates {
+		title := "MNB base rate " + outLocale.FormatDouble(rate.Rate) + "%"
+		body := "<p>The central bank base rate is <b>" + outLocale.FormatDouble(rate.Rate) + "%</b> from " + rate.Publication.String()
+		if i+1 < len(rates) {
+			prev := outLocale.FormatDouble(rates[i+1].Rate) + "%"
+			title += " (from " + prev + ")"
+			body += ", was " + prev + " from " + rates[i+1].Publication.String()
+		}
+		feed.Entries = append(feed.Entries, atomEntry{
+			ID:      "urn:mnbarf:baserate:" + rate.Publication.String(),
+			Title:   title + ", effective " + rate.Publication.String(),
+			Updated: feedTime(rate.Publication),
+			Links:   []atomLink{{Rel: "alternate", Type: "application/json", Href: base + "/baserate/at/" + rate.Publication.String()}},
+			Content: atomContent{Type: "html", Body: body + ".</p>"},
+		})
+	}
+	writeAtom(w, r, feed, nil)
+}
@@ -0,0 +1,139 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tgulacsi/mnbarf/mnb"
+)
+
+// getFeed GETs the path from the API server in front of the fake, and parses the Atom feed.
+func getFeed(t *testing.T, api http.Handler, path string) atomFeed {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	api.ServeHTTP(rec, httptest.NewRequest("GET", "http://example.com"+path, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: got %d: %s", path, rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/atom+xml") {
+		t.Errorf("%s: got Content-Type %q", path, ct)
+	}
+	var feed atomFeed
+	if err := xml.Unmarshal(rec.Body.Bytes(), &feed); err != nil {
+		t.Fatalf("%s: %+v\n%s", path, err, rec.Body.String())
+	}
+	return feed
+}
+
+func TestRatesFeed(t *testing.T) {
+	now := time.Now()
+	day := func(days int) string { return now.AddDate(0, 0, -days).Format("2006-01-02") }
+	fake := fakeMNB{Days: testRates(t,
+		day(60)+" EUR=380.00 USD=350.00",
+		day(3)+" EUR=390.00 USD=360.00",
+		day(2)+" EUR=393.90 USD=360.00",
+	)}
+	srv := fake.serve(t)
+	api := newAPIServer(mnb.NewMNBArfolyamService(srv.URL, nil, nil), mnb.NewMNBAlapkamatService(srv.URL, nil, nil))
+
+	feed := getFeed(t, api, "/feed/rates.atom")
+	if feed.ID != "urn:mnbarf:feed:rates" {
+		t.Errorf("got feed ID %q", feed.ID)
+	}
+	if len(feed.Entries) != 2 {
+		t.Fatalf("got %d entries, wanted the 2 of the last 30 days", len(feed.Entries))
+	}
+	if got, want := feed.Entries[0].ID, "urn:mnbarf:rates:"+day(2); got != want {
+		t.Errorf("got first entry %q, wanted the newest %q", got, want)
+	}
+	if !feed.Updated.Equal(feed.Entries[0].Updated) {
+		t.Errorf("feed updated %s, newest entry %s", feed.Updated, feed.Entries[0].Updated)
+	}
+	body := feed.Entries[0].Content.Body
+	for _, want := range []string{"<td>EUR</td>", "<td>USD</td>", "%</td>"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("no %q in %s", want, body)
+		}
+	}
+	if len(feed.Links) != 1 || feed.Links[0].Href != "http://example.com/feed/rates.atom" {
+		t.Errorf("got links %+v", feed.Links)
+	}
+
+	feed = getFeed(t, api, "/feed/rates.atom?days=90&currency=eur")
+	if len(feed.Entries) != 3 {
+		t.Fatalf("got %d entries, wanted 3 in 90 days", len(feed.Entries))
+	}
+	for _, e := range feed.Entries {
+		if strings.Contains(e.Content.Body, "USD") {
+			t.Errorf("%s: USD is not asked for: %s", e.ID, e.Content.Body)
+		}
+	}
+}
+
+func TestBaseRateFeed(t *testing.T) {
+	now := time.Now()
+	fake := fakeMNB{BaseRates: make([]mnb.MNBBaseRate, 3)}
+	for i, s := range []string{"13.00", "7.75", "6.50"} {
+		r := &fake.BaseRates[i]
+		if err := r.Publication.UnmarshalText([]byte(now.AddDate(-10+4*i, 0, 0).Format("2006-01-02"))); err != nil {
+			t.Fatal(err)
+		}
+		r.Rate = testRate(t, "HUF", 1, s).Rate
+	}
+	srv := fake.serve(t)
+	api := newAPIServer(mnb.NewMNBArfolyamService(srv.URL, nil, nil), mnb.NewMNBAlapkamatService(srv.URL, nil, nil))
+
+	feed := getFeed(t, api, "/feed/baserate.atom")
+	if feed.ID != "urn:mnbarf:feed:baserate" {
+		t.Errorf("got feed ID %q", feed.ID)
+	}
+	if len(feed.Entries) != 1 {
+		t.Fatalf("got %d entries, wanted the 1 change of the last 5 years", len(feed.Entries))
+	}
+	if got, want := feed.Entries[0].ID, "urn:mnbarf:baserate:"+fake.BaseRates[2].Publication.String(); got != want {
+		t.Errorf("got entry %q, wanted %q", got, want)
+	}
+
+	feed = getFeed(t, api, "/feed/baserate.atom?years=7")
+	if len(feed.Entries) != 2 {
+		t.Fatalf("got %d entries, wanted 2 in 7 years", len(feed.Entries))
+	}
+	if title := feed.Entries[0].Title; !strings.Contains(title, "(from ") {
+		t.Errorf("no previous rate in %q", title)
+	}
+}
+
+func TestFeedBadRequest(t *testing.T) {
+	var fake fakeMNB
+	srv := fake.serve(t)
+	api := newAPIServer(mnb.NewMNBArfolyamService(srv.URL, nil, nil), mnb.NewMNBAlapkamatService(srv.URL, nil, nil))
+	for _, path := range []string{
+		"/feed/rates.atom?days=0",
+		"/feed/rates.atom?days=x",
+		"/feed/rates.atom?days=367",
+		"/feed/baserate.atom?years=31",
+	} {
+		rec := httptest.NewRecorder()
+		api.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: got %d, wanted 400", path, rec.Code)
+			continue
+		}
+		var resp struct{ Error string }
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || resp.Error == "" {
+			t.Errorf("%s: got %q (%v)", path, rec.Body.String(), err)
+		}
+	}
+	if n := fake.Calls("GetExchangeRates") + fake.Calls("GetCentralBankBaseRate"); n != 0 {
+		t.Errorf("got %d calls of MNB for bad requests", n)
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/tgulacsi/mnbarf/mnb"
+	"github.com/tgulacsi/mnbarf/mnbpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// grpcServer implements mnbpb.MNBServiceServer with the MNB services.
+type grpcServer struct {
+	mnbpb.UnimplementedMNBServiceServer
+	wsC mnb.MNBArfolyamService
+	wsR mnb.MNBAlapkamatService
+}
+
+// streamChunkDays is the length of the periods StreamRates asks from MNB at once.
+const streamChunkDays = 92
+
+// grpcError converts the error to a gRPC status.
+func grpcError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, errBadRequest):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, mnb.ErrNoRate):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	return status.Error(codes.Unavailable, err.Error())
+}
+
+func toPBDayRates(day mnb.DayRates) *mnbpb.DayRates {
+	d := mnbpb.DayRates{Day: day.Day.String(), Rates: make([]*mnbpb.Rate, len(day.Rates))}
+	for i, r := range day.Rates {
+		d.Rates[i] = &mnbpb.Rate{Currency: r.Currency, Unit: int32(r.Unit), Rate: r.Rate.String()}
+	}
+	return &d
+}
+
+func (s grpcServer) ratesRequest(req *mnbpb.GetRatesRequest) (begin, end time.Time, currencies []string, err error) {
+	if begin, end, err = parseDates(req.GetFrom(), req.GetTo()); err != nil {
+		return begin, end, nil, grpcError(errors.Join(errBadRequest, err))
+	}
+	for _, c := range req.GetCurrencies() {
+		if c = strings.ToUpper(strings.TrimSpace(c)); c != "" {
+			currencies = append(currencies, c)
+		}
+	}
+	if len(currencies) == 0 {
+		return begin, end, nil, status.Error(codes.InvalidArgument, "at least one currency is needed")
+	}
+	return begin, end, currencies, nil
+}
+
+func (s grpcServer) GetRates(ctx context.Context, req *mnbpb.GetRatesRequest) (*mnbpb.GetRatesResponse, error) {
+	begin, end, currencies, err := s.ratesRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	days, err := s.wsC.GetExchangeRates(ctx, begin, end, currencies...)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp := mnbpb.GetRatesResponse{Days: make([]*mnbpb.DayRates, len(days))}
+	for i, day := range days {
+		resp.Days[i] = toPBDayRates(day)
+	}
+	return &resp, nil
+}
+
+func (s grpcServer) StreamRates(req *mnbpb.GetRatesRequest, stream grpc.ServerStreamingServer[mnbpb.DayRates]) error {
+	begin, end, currencies, err := s.ratesRequest(req)
+	if err != nil {
+		return err
+	}
+	ctx := stream.Context()
+	for !begin.After(end) {
+		chunkEnd := begin.AddDate(0, 0, streamChunkDays-1)
+		if chunkEnd.After(end) {
+			chunkEnd = end
+		}
+		days, err := s.wsC.GetExchangeRates(ctx, begin, chunkEnd, currencies...)
+		if err != nil {
+			return grpcError(err)
+		}
+		slices.SortFunc(days, func(a, b mnb.DayRates) int { return time.Time(a.Day).Compare(time.Time(b.Day)) })
+		for _, day := range days {
+			if err := stream.Send(toPBDayRates(day)); err != nil {
+				return err
+			}
+		}
+		begin = chunkEnd.AddDate(0, 0, 1)
+	}
+	return nil
+}
+
+func (s grpcServer) Convert(ctx context.Context, req *mnbpb.ConvertRequest) (*mnbpb.ConvertResponse, error) {
+	from, to := strings.ToUpper(req.GetFrom()), strings.ToUpper(req.GetTo())
+	if from == "" {
+		return nil, status.Error(codes.InvalidArgument, "from is needed")
+	}
+	if to == "" {
+		to = "HUF"
+	}
+	amountS := req.GetAmount()
+	if amountS == "" {
+		amountS = "1"
+	}
+	amount, err := mnb.NewDoubleFromString(strings.Replace(amountS, ",", ".", 1))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "amount=%q: %v", amountS, err)
+	}
+	var day time.Time
+	if req.GetDate() != "" {
+		if day, err = time.Parse("2006-01-02", req.GetDate()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "date=%q: %v", req.GetDate(), err)
+		}
+	}
+	rates, err := ratesFor(ctx, s.wsC, day, from, to)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	rate, err := rates.CrossRate(from, to)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	result, err := rates.Convert(amount, from, to)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &mnbpb.ConvertResponse{
+		Amount: amount.String(), From: from, To: to, Day: rates.Day.String(),
+		Rate: rate.Round(8).String(), Result: result.Round(4).String(),
+	}, nil
+}
+
+func (s grpcServer) GetBaseRate(ctx context.Context, req *mnbpb.GetBaseRateRequest) (*mnbpb.BaseRate, error) {
+	var rate mnb.MNBBaseRate
+	var err error
+	if req.GetDate() == "" {
+		rate, err = s.wsR.GetCurrentBaseRate(ctx)
+	} else {
+		var day time.Time
+		if day, err = time.Parse("2006-01-02", req.GetDate()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "date=%q: %v", req.GetDate(), err)
+		}
+		rate, err = s.wsR.GetBaseRateAt(ctx, day)
+	}
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &mnbpb.BaseRate{Publication: rate.Publication.String(), Rate: rate.Rate.String()}, nil
+}
+
+func (s grpcServer) ListCurrencies(ctx context.Context, req *mnbpb.ListCurrenciesRequest) (*mnbpb.ListCurrenciesResponse, error) {
+	currencies, err := s.wsC.GetCurrencies(ctx)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &mnbpb.ListCurrenciesResponse{Currencies: currencies}, nil
+}
+
+// serveGRPC serves the MNBService on the listener till the context is canceled.
+func serveGRPC(ctx context.Context, lis net.Listener, wsC mnb.MNBArfolyamService, wsR mnb.MNBAlapkamatService) error {
+	srv := grpc.NewServer()
+	mnbpb.RegisterMNBServiceServer(srv, grpcServer{wsC: wsC, wsR: wsR})
+	go func() {
+		<-ctx.Done()
+		srv.GracefulStop()
+	}()
+	logger.Info("gRPC listening", "addr", lis.Addr().String())
+	return srv.Serve(lis)
+}
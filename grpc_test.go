@@ -0,0 +1,181 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"slices"
+	"testing"
+
+	"github.com/cockroachdb/apd/v3"
+	"github.com/tgulacsi/mnbarf/mnb"
+	"github.com/tgulacsi/mnbarf/mnbpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestGRPCClient serves the MNBService with the fake MNB on an in-process listener.
+func newTestGRPCClient(t *testing.T, fake *fakeMNB) (mnbpb.MNBServiceClient, *fakeMNB) {
+	t.Helper()
+	srv := fake.serve(t)
+	lis := bufconn.Listen(1 << 20)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- serveGRPC(ctx, lis,
+			mnb.NewMNBArfolyamService(srv.URL, nil, nil), mnb.NewMNBAlapkamatService(srv.URL, nil, nil))
+	}()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Error(err)
+		}
+	})
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return mnbpb.NewMNBServiceClient(conn), fake
+}
+
+// the rates have more digits than MNB publishes, to check that nothing is lost on the way
+var grpcTestRates = []string{
+	"2024-01-31 EUR=388.123456789012345678901 USD=357.5",
+	"2024-05-02 EUR=391.00 USD=365.00 JPY/100=235.00",
+	"2024-05-03 EUR=390.50 USD=364.10 JPY/100=236.5012345678901234",
+}
+
+func TestGRPCGetRates(t *testing.T) {
+	client, _ := newTestGRPCClient(t, &fakeMNB{Days: testRates(t, grpcTestRates...)})
+	resp, err := client.GetRates(context.Background(), &mnbpb.GetRatesRequest{
+		From: "2024-01-01", To: "2024-05-31", Currencies: []string{"eur", "JPY"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]string)
+	for _, d := range resp.GetDays() {
+		for _, r := range d.GetRates() {
+			got[d.GetDay()+" "+r.GetCurrency()] = r.GetRate()
+			if r.GetCurrency() == "JPY" && r.GetUnit() != 100 {
+				t.Errorf("JPY unit is %d", r.GetUnit())
+			}
+		}
+	}
+	for k, want := range map[string]string{
+		"2024-01-31 EUR": "388.123456789012345678901",
+		"2024-05-03 JPY": "236.5012345678901234",
+		"2024-05-02 EUR": "391.00",
+	} {
+		if got[k] != want {
+			t.Errorf("%s: got %q, wanted %q", k, got[k], want)
+		}
+	}
+	if _, ok := got["2024-05-03 USD"]; ok {
+		t.Error("USD is not asked for")
+	}
+
+	_, err = client.GetRates(context.Background(), &mnbpb.GetRatesRequest{From: "2024-01-01"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("no currencies: got %v, wanted InvalidArgument", err)
+	}
+	_, err = client.GetRates(context.Background(), &mnbpb.GetRatesRequest{From: "2024.01.01", Currencies: []string{"EUR"}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("bad date: got %v, wanted InvalidArgument", err)
+	}
+}
+
+func TestGRPCStreamRates(t *testing.T) {
+	client, fake := newTestGRPCClient(t, &fakeMNB{Days: testRates(t, grpcTestRates...)})
+	stream, err := client.StreamRates(context.Background(), &mnbpb.GetRatesRequest{
+		From: "2023-12-01", To: "2024-05-31", Currencies: []string{"EUR"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var days, rates []string
+	for {
+		d, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		days = append(days, d.GetDay())
+		for _, r := range d.GetRates() {
+			rates = append(rates, r.GetRate())
+		}
+	}
+	if want := []string{"2024-01-31", "2024-05-02", "2024-05-03"}; !slices.Equal(days, want) {
+		t.Errorf("got days %q, wanted %q", days, want)
+	}
+	if want := []string{"388.123456789012345678901", "391.00", "390.50"}; !slices.Equal(rates, want) {
+		t.Errorf("got rates %q, wanted %q", rates, want)
+	}
+	// 183 days in chunks of streamChunkDays
+	if n := fake.Calls("GetExchangeRates"); n != 2 {
+		t.Errorf("got %d GetExchangeRates calls, wanted 2", n)
+	}
+}
+
+func TestGRPCConvert(t *testing.T) {
+	client, _ := newTestGRPCClient(t, &fakeMNB{Days: testRates(t, grpcTestRates...)})
+	const amount = "12345678901234567890.123456789"
+	// 2024-05-04 is a Saturday: the rates of Friday are used
+	resp, err := client.Convert(context.Background(), &mnbpb.ConvertRequest{
+		Amount: amount, From: "JPY", To: "HUF", Date: "2024-05-04",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetDay() != "2024-05-03" {
+		t.Errorf("got day %q, wanted 2024-05-03", resp.GetDay())
+	}
+	if resp.GetAmount() != amount {
+		t.Errorf("got amount %q, wanted %q", resp.GetAmount(), amount)
+	}
+	// 236.5012345678901234 HUF / 100 JPY
+	if want := "2.36501235"; resp.GetRate() != want {
+		t.Errorf("got rate %q, wanted %q", resp.GetRate(), want)
+	}
+	var a, r, want apd.Decimal
+	a.SetString(amount)
+	r.SetString("2.365012345678901234")
+	apd.BaseContext.WithPrecision(100).Mul(&want, &a, &r)
+	apd.BaseContext.WithPrecision(100).Quantize(&want, &want, -4)
+	if resp.GetResult() != want.Text('f') {
+		t.Errorf("got result %q, wanted %q", resp.GetResult(), want.Text('f'))
+	}
+
+	_, err = client.Convert(context.Background(), &mnbpb.ConvertRequest{Amount: "1", From: "CHF", Date: "2024-05-03"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("unknown currency: got %v, wanted NotFound", err)
+	}
+	_, err = client.Convert(context.Background(), &mnbpb.ConvertRequest{Amount: "x", From: "EUR"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("bad amount: got %v, wanted InvalidArgument", err)
+	}
+}
+
+func TestGRPCListCurrencies(t *testing.T) {
+	client, _ := newTestGRPCClient(t, &fakeMNB{Days: testRates(t, grpcTestRates...)})
+	resp, err := client.ListCurrencies(context.Background(), &mnbpb.ListCurrenciesRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"HUF", "EUR", "USD", "JPY"}; !slices.Equal(resp.GetCurrencies(), want) {
+		t.Errorf("got %q, wanted %q", resp.GetCurrencies(), want)
+	}
+}
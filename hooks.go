@@ -0,0 +1,231 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tgulacsi/mnbarf/mnb"
+)
+
+// hookEvent is the JSON payload sent to the hooks.
+type hookEvent struct {
+	// Event is "rates" for a new day of exchange rates, "baserate" for a new base rate,
+	// "alert" for fired alert rules.
+	Event    string
+	Detected time.Time
+	Rates    *mnb.DayRates    `json:",omitempty"`
+	BaseRate *mnb.MNBBaseRate `json:",omitempty"`
+	Alerts   []alert          `json:",omitempty"`
+}
+
+// hook delivers the payload somewhere.
+type hook interface {
+	Name() string
+	Deliver(ctx context.Context, event string, payload []byte) error
+}
+
+// webhook POSTs the payload to URL, signed with HMAC-SHA256 of Secret
+// in the X-MNB-Signature header (as sha256=<hex>).
+type webhook struct {
+	URL    string
+	Secret string
+	Client *http.Client
+}
+
+func (h webhook) Name() string { return h.URL }
+
+// hookSignature returns the value of the X-MNB-Signature header for the payload.
+func hookSignature(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (h webhook) Deliver(ctx context.Context, event string, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", h.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-MNB-Event", event)
+	if h.Secret != "" {
+		req.Header.Set("X-MNB-Signature", hookSignature(h.Secret, payload))
+	}
+	client := h.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("POST %q: %s: %s", h.URL, resp.Status, b)
+	}
+	return nil
+}
+
+// commandHook runs the shell command with the payload on its stdin,
+// and the event in the MNB_EVENT environment variable.
+type commandHook struct {
+	Command string
+}
+
+func (h commandHook) Name() string { return h.Command }
+
+func (h commandHook) Deliver(ctx context.Context, event string, payload []byte) error {
+	cmd := exec.CommandContext(ctx, "sh", "-c", h.Command)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stdout, cmd.Stderr = os.Stderr, os.Stderr
+	cmd.Env = append(os.Environ(), "MNB_EVENT="+event)
+	return cmd.Run()
+}
+
+// hookDispatcher delivers the events to all the hooks, retrying the failed deliveries,
+// and appending the undeliverable ones to the DeadLetter file (one JSON per line).
+// Each delivery attempt is canceled after Timeout (if positive), so a hanging hook
+// doesn't block the caller.
+type hookDispatcher struct {
+	Hooks      []hook
+	Retries    int
+	Backoff    time.Duration
+	Timeout    time.Duration
+	DeadLetter string
+
+	mu sync.Mutex
+}
+
+// deadLetter is a line of the dead letter file.
+type deadLetter struct {
+	Hook    string
+	Event   string
+	Time    time.Time
+	Error   string
+	Payload json.RawMessage
+}
+
+// Fire delivers the event to all hooks.
+func (d *hookDispatcher) Fire(ctx context.Context, ev hookEvent) error {
+	if d == nil || len(d.Hooks) == 0 {
+		return nil
+	}
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, h := range d.Hooks {
+		if err := d.deliver(ctx, h, ev.Event, payload); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", h.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (d *hookDispatcher) deliver(ctx context.Context, h hook, event string, payload []byte) error {
+	backoff := d.Backoff
+	var err error
+	for i := 0; i <= d.Retries; i++ {
+		if i != 0 {
+			logger.Warn("hook retry", "hook", h.Name(), "event", event, "error", err, "wait", backoff)
+			select {
+			case <-ctx.Done():
+				return errors.Join(err, ctx.Err())
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+		if err = d.attempt(ctx, h, event, payload); err == nil {
+			return nil
+		}
+	}
+	logger.Error("hook failed", "hook", h.Name(), "event", event, "error", err)
+	if d.DeadLetter == "" {
+		return err
+	}
+	b, mErr := json.Marshal(deadLetter{Hook: h.Name(), Event: event, Time: time.Now(), Error: err.Error(), Payload: payload})
+	if mErr != nil {
+		return errors.Join(err, mErr)
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	fh, fErr := os.OpenFile(d.DeadLetter, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0640)
+	if fErr != nil {
+		return errors.Join(err, fErr)
+	}
+	_, wErr := fh.Write(append(b, '\n'))
+	if cErr := fh.Close(); wErr == nil {
+		wErr = cErr
+	}
+	return errors.Join(err, wErr)
+}
+
+// attempt delivers the payload once, in Timeout.
+func (d *hookDispatcher) attempt(ctx context.Context, h hook, event string, payload []byte) error {
+	if d.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.Timeout)
+		defer cancel()
+	}
+	return h.Deliver(ctx, event, payload)
+}
+
+// hookFlags registers the hook flags on fs, and returns a function which builds the dispatcher from them,
+// with the default dead letter file.
+func hookFlags(fs *flag.FlagSet) func(deadLetter string) *hookDispatcher {
+	var execs, webhooks stringsFlag
+	fs.Var(&execs, "exec", "run this shell command with the event as JSON on stdin (repeatable)")
+	fs.Var(&webhooks, "webhook", "POST the event as JSON to this URL (repeatable)")
+	flagSecret := fs.String("webhook-secret", "", "sign the webhook payloads with HMAC-SHA256 using this secret, in the X-MNB-Signature header (default: $MNB_WEBHOOK_SECRET)")
+	flagRetries := fs.Int("hook-retries", 5, "retry the failed hooks this many times")
+	flagBackoff := fs.Duration("hook-backoff", 2*time.Second, "initial wait between the hook retries (doubled each time)")
+	flagTimeout := fs.Duration("hook-timeout", 30*time.Second, "cancel a hook delivery attempt after this long")
+	flagDeadLetter := fs.String("dead-letter", "", "append the undeliverable hook events to this file")
+	return func(deadLetter string) *hookDispatcher {
+		d := hookDispatcher{Retries: *flagRetries, Backoff: *flagBackoff, Timeout: *flagTimeout, DeadLetter: *flagDeadLetter}
+		if d.DeadLetter == "" {
+			d.DeadLetter = deadLetter
+		}
+		// not the default of the flag, to not print it in the usage
+		secret := *flagSecret
+		if secret == "" {
+			secret = os.Getenv("MNB_WEBHOOK_SECRET")
+		}
+		for _, u := range webhooks {
+			d.Hooks = append(d.Hooks, webhook{URL: u, Secret: secret})
+		}
+		for _, c := range execs {
+			d.Hooks = append(d.Hooks, commandHook{Command: c})
+		}
+		return &d
+	}
+}
+
+// stringsFlag is a repeatable string flag.
+type stringsFlag []string
+
+func (ss *stringsFlag) String() string { return strings.Join(*ss, ",") }
+func (ss *stringsFlag) Set(s string) error {
+	*ss = append(*ss, s)
+	return nil
+}
@@ -0,0 +1,161 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHookFlagsSecret(t *testing.T) {
+	const secret = "s3cr3t-from-env"
+	t.Setenv("MNB_WEBHOOK_SECRET", secret)
+	for _, tc := range []struct {
+		Args []string
+		Want string
+	}{
+		{Args: []string{"-webhook=http://localhost/hook"}, Want: secret},
+		{Args: []string{"-webhook=http://localhost/hook", "-webhook-secret=flag"}, Want: "flag"},
+	} {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		hooks := hookFlags(fs)
+		var usage strings.Builder
+		fs.SetOutput(&usage)
+		fs.PrintDefaults()
+		if strings.Contains(usage.String(), secret) {
+			t.Errorf("the usage shows the secret:\n%s", usage.String())
+		}
+		if err := fs.Parse(tc.Args); err != nil {
+			t.Fatal(err)
+		}
+		d := hooks("")
+		if len(d.Hooks) != 1 {
+			t.Fatalf("%q: got %d hooks", tc.Args, len(d.Hooks))
+		}
+		if got := d.Hooks[0].(webhook).Secret; got != tc.Want {
+			t.Errorf("%q: got secret %q, wanted %q", tc.Args, got, tc.Want)
+		}
+	}
+}
+
+func TestWebhookDeliver(t *testing.T) {
+	const secret = "s3cr3t"
+	payload := []byte(`{"Event":"rates"}`)
+	var got *http.Request
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer srv.Close()
+	if err := (webhook{URL: srv.URL, Secret: secret}).Deliver(context.Background(), "rates", payload); err != nil {
+		t.Fatal(err)
+	}
+	if got.Method != "POST" || got.Header.Get("X-MNB-Event") != "rates" || got.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("got %s with headers %v", got.Method, got.Header)
+	}
+	if !bytes.Equal(body, payload) {
+		t.Errorf("got body %q, wanted %q", body, payload)
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	if got, want := got.Header.Get("X-MNB-Signature"), "sha256="+hex.EncodeToString(mac.Sum(nil)); got != want {
+		t.Errorf("got signature %q, wanted %q", got, want)
+	}
+
+	if err := (webhook{URL: srv.URL}).Deliver(context.Background(), "rates", payload); err != nil {
+		t.Fatal(err)
+	}
+	if sig, ok := got.Header["X-Mnb-Signature"]; ok {
+		t.Errorf("got signature %q without secret", sig)
+	}
+}
+
+func TestHookDispatcher(t *testing.T) {
+	ev := hookEvent{Event: "rates", Detected: time.Date(2024, 5, 3, 11, 50, 0, 0, time.UTC)}
+	for _, tc := range []struct {
+		Name string
+		// Fails is the number of failing responses before the first success.
+		Fails, Retries int
+		Hang           bool
+		Calls          int
+		DeadLetter     bool
+	}{
+		{Name: "ok", Retries: 2, Calls: 1},
+		{Name: "retry on 5xx", Fails: 2, Retries: 2, Calls: 3},
+		{Name: "dead letter", Fails: 3, Retries: 2, Calls: 3, DeadLetter: true},
+		{Name: "timeout", Hang: true, Retries: 1, Calls: 2, DeadLetter: true},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			var calls atomic.Int32
+			done := make(chan struct{})
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(calls.Add(1))
+				if tc.Hang {
+					select {
+					case <-r.Context().Done():
+					case <-done:
+					}
+					return
+				}
+				if n <= tc.Fails {
+					http.Error(w, "try again", http.StatusServiceUnavailable)
+				}
+			}))
+			defer srv.Close()
+			defer close(done)
+			fn := filepath.Join(t.TempDir(), "dead-letter.jsonl")
+			d := hookDispatcher{Hooks: []hook{webhook{URL: srv.URL}},
+				Retries: tc.Retries, Backoff: time.Millisecond, Timeout: 100 * time.Millisecond, DeadLetter: fn}
+			err := d.Fire(context.Background(), ev)
+			if (err != nil) != tc.DeadLetter {
+				t.Errorf("got error %v", err)
+			}
+			if n := int(calls.Load()); n != tc.Calls {
+				t.Errorf("got %d calls, wanted %d", n, tc.Calls)
+			}
+			b, err := os.ReadFile(fn)
+			if !tc.DeadLetter {
+				if !errors.Is(err, os.ErrNotExist) {
+					t.Errorf("got dead letter file %q (%v)", b, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			lines := strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+			if len(lines) != 1 {
+				t.Fatalf("got %d dead letters: %q", len(lines), b)
+			}
+			var dl deadLetter
+			if err := json.Unmarshal([]byte(lines[0]), &dl); err != nil {
+				t.Fatal(err)
+			}
+			var payload hookEvent
+			if err := json.Unmarshal(dl.Payload, &payload); err != nil {
+				t.Fatal(err)
+			}
+			if dl.Hook != srv.URL || dl.Event != "rates" || dl.Error == "" || !payload.Detected.Equal(ev.Detected) {
+				t.Errorf("got dead letter %+v", dl)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/tgulacsi/mnbarf/mnb"
+)
+
+// printInvoiceRates prints the candidate invoice exchange rates in the output format:
+// json and the templates get all the fields (the "row" template each rate),
+// csv, html and markdown a row for each rate.
+// The exchange rates are always printed as the NAV Online Invoice expects them (with a decimal point),
+// not in the locale of the output.
+func printInvoiceRates(w io.Writer, rates []mnb.InvoiceRate, outFormat string) error {
+	bw := bufio.NewWriter(w)
+	defer bw.Flush()
+
+	switch outFormat {
+	case "json":
+		enc := json.NewEncoder(bw)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rates)
+
+	case "csv", "html", "markdown", "md":
+		t := table{
+			Title:   "Invoice exchange rates",
+			Columns: []string{"source", "currency", "supply_day", "rate_day", "exchange_rate", "quote", "rule"},
+		}
+		if len(rates) != 0 {
+			t.Title = fmt.Sprintf("Invoice exchange rates of %s for the day of supply %s",
+				rates[0].Currency, outLocale.FormatDate(rates[0].SupplyDay))
+		}
+		for _, r := range rates {
+			t.Rows = append(t.Rows, []string{
+				r.Source, r.Currency, outLocale.FormatDate(r.SupplyDay), outLocale.FormatDate(r.Day),
+				r.ExchangeRate.String(), r.Quote, r.Rule,
+			})
+		}
+		switch outFormat {
+		case "csv":
+			return t.WriteCSV(bw)
+		case "html":
+			return t.WriteHTML(bw)
+		}
+		return t.WriteMarkdown(bw)
+
+	case "sql":
+		return fmt.Errorf("the sql format is not supported for invoice rates")
+
+	default: // template
+		tmpl, err := parseOutTemplate(outFormat)
+		if err != nil {
+			logger.Info("template parse", "error", err)
+			return err
+		}
+		if err := executeOutTemplate(bw, tmpl, rates, rates); err != nil {
+			logger.Info("template execute", "error", err)
+			return err
+		}
+	}
+	return nil
+}
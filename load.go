@@ -0,0 +1,261 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/apd/v3"
+	"github.com/tgulacsi/mnbarf/mnb"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	_ "modernc.org/sqlite"
+)
+
+// loadStats counts the outcome of a load.
+type loadStats struct {
+	Inserted, Updated, Unchanged int
+}
+
+func (s loadStats) String() string {
+	return fmt.Sprintf("inserted=%d updated=%d unchanged=%d", s.Inserted, s.Updated, s.Unchanged)
+}
+
+// driverName returns the database/sql driver name for the dialect.
+func (t sqlTable) driverName() string {
+	switch t.Dialect {
+	case "oracle":
+		return "godror"
+	case "sqlite":
+		return "sqlite"
+	default:
+		return "pgx"
+	}
+}
+
+// open opens the database with the driver of the dialect.
+func (t sqlTable) open(dsn string) (*sql.DB, error) {
+	name := t.driverName()
+	if !slices.Contains(sql.Drivers(), name) {
+		return nil, fmt.Errorf("the %s driver is not built in (build with -tags=%s)", name, name)
+	}
+	db, err := sql.Open(name, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", name, err)
+	}
+	return db, nil
+}
+
+// placeholder returns the i-th (1-based) bind placeholder.
+func (t sqlTable) placeholder(i int) string {
+	switch t.Dialect {
+	case "oracle":
+		return ":" + strconv.Itoa(i)
+	case "sqlite":
+		return "?"
+	default:
+		return "$" + strconv.Itoa(i)
+	}
+}
+
+// bindDate returns the date as a bind parameter.
+//
+// SQLite has no DATE type, so it gets the same ISO string as the sql output format.
+func (t sqlTable) bindDate(d mnb.Date) any {
+	if t.Dialect == "sqlite" {
+		return d.String()
+	}
+	return time.Time(d)
+}
+
+// maxBindVars is the maximum number of bind variables in one statement:
+// the default limit of SQLite, which is the lowest of the supported databases.
+const maxBindVars = 32766
+
+// loadRows upserts the rows (values in the order of t.Columns) in batches,
+// in one transaction, and reports how many rows were inserted, updated or left unchanged.
+//
+// The first column must be the day, as existing rows are queried by day range for each batch.
+// The new and changed rows of a batch are sent in one multi-row upsert statement.
+func (t sqlTable) loadRows(ctx context.Context, db *sql.DB, rows [][]any, batchSize int) (loadStats, error) {
+	var stats loadStats
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+	if n := maxBindVars / len(t.Columns); batchSize > n {
+		batchSize = n
+	}
+	qrySelect := fmt.Sprintf("SELECT %s FROM %s WHERE %s BETWEEN %s AND %s",
+		strings.Join(t.Columns, ", "), t.Name, t.Columns[0], t.placeholder(1), t.placeholder(2))
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return stats, err
+	}
+	defer tx.Rollback()
+
+	key := func(values []string) string { return strings.Join(values[:t.KeyN], "\x00") }
+	existing := make(map[string][]string)
+	pending := make(map[string]int)
+	var upserts [][]any
+	for len(rows) != 0 {
+		batch := rows[:min(batchSize, len(rows))]
+		rows = rows[len(batch):]
+
+		first, last := canonicalSQLValue(batch[0][0]), canonicalSQLValue(batch[0][0])
+		for _, row := range batch[1:] {
+			if s := canonicalSQLValue(row[0]); s < first {
+				first = s
+			} else if s > last {
+				last = s
+			}
+		}
+		clear(existing)
+		if err := func() error {
+			var firstD, lastD mnb.Date
+			if err := firstD.UnmarshalText([]byte(first)); err != nil {
+				return err
+			}
+			if err := lastD.UnmarshalText([]byte(last)); err != nil {
+				return err
+			}
+			dbRows, err := tx.QueryContext(ctx, qrySelect, t.bindDate(firstD), t.bindDate(lastD))
+			if err != nil {
+				return fmt.Errorf("%s: %w", qrySelect, err)
+			}
+			defer dbRows.Close()
+			dest := make([]any, len(t.Columns))
+			for dbRows.Next() {
+				values := make([]any, len(t.Columns))
+				for i := range values {
+					dest[i] = &values[i]
+				}
+				if err := dbRows.Scan(dest...); err != nil {
+					return err
+				}
+				ss := make([]string, len(values))
+				for i, v := range values {
+					ss[i] = canonicalSQLValue(v)
+				}
+				existing[key(ss)] = ss
+			}
+			return dbRows.Err()
+		}(); err != nil {
+			return stats, err
+		}
+
+		clear(pending)
+		upserts = upserts[:0]
+		for _, row := range batch {
+			ss := make([]string, len(t.Columns))
+			for i, v := range row {
+				ss[i] = canonicalSQLValue(v)
+			}
+			k := key(ss)
+			old, ok := existing[k]
+			if ok {
+				changed := false
+				for i := t.KeyN; i < len(ss) && !changed; i++ {
+					changed = old[i] != ss[i]
+				}
+				if !changed {
+					stats.Unchanged++
+					continue
+				}
+				stats.Updated++
+			} else {
+				stats.Inserted++
+			}
+			existing[k] = ss
+			// a repeated key would make the upsert affect the same row twice
+			if i, ok := pending[k]; ok {
+				upserts[i] = row
+				continue
+			}
+			pending[k] = len(upserts)
+			upserts = append(upserts, row)
+		}
+		if err := t.upsert(ctx, tx, upserts); err != nil {
+			return stats, err
+		}
+	}
+	return stats, tx.Commit()
+}
+
+// upsert inserts or updates the rows with one statement.
+func (t sqlTable) upsert(ctx context.Context, tx *sql.Tx, rows [][]any) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	ph := make([][]string, len(rows))
+	args := make([]any, 0, len(rows)*len(t.Columns))
+	for i, row := range rows {
+		ph[i] = make([]string, len(row))
+		for j := range row {
+			ph[i][j] = t.placeholder(len(args) + 1)
+			args = append(args, row[j])
+		}
+	}
+	qry := t.statement(true, ph)
+	if _, err := tx.ExecContext(ctx, qry, args...); err != nil {
+		return fmt.Errorf("%s: %w", t.statement(true, ph[:1]), err)
+	}
+	return nil
+}
+
+// canonicalSQLValue returns the value as a string comparable between what
+// we bind and what the database returns: dates as 2006-01-02, numbers without trailing zeros.
+func canonicalSQLValue(v any) string {
+	var s string
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		return x.Format("2006-01-02")
+	case mnb.Date:
+		return x.String()
+	case string:
+		s = x
+	case []byte:
+		s = string(x)
+	default:
+		s = fmt.Sprint(x)
+	}
+	if len(s) >= 10 && s[4] == '-' && s[7] == '-' {
+		if _, err := time.Parse("2006-01-02", s[:10]); err == nil {
+			return s[:10]
+		}
+	}
+	var d apd.Decimal
+	if _, _, err := d.SetString(s); err == nil {
+		d.Reduce(&d)
+		return d.Text('f')
+	}
+	return s
+}
+
+func loadDayRates(ctx context.Context, db *sql.DB, t sqlTable, days []mnb.DayRates, batchSize int) (loadStats, error) {
+	rows := make([][]any, 0, len(days)*4)
+	for _, day := range days {
+		for _, rate := range day.Rates {
+			rows = append(rows, []any{t.bindDate(day.Day), rate.Currency, rate.Unit, rate.Rate.String()})
+		}
+	}
+	return t.loadRows(ctx, db, rows, batchSize)
+}
+
+func loadBaseRates(ctx context.Context, db *sql.DB, t sqlTable, rates []mnb.MNBBaseRate, batchSize int) (loadStats, error) {
+	rows := make([][]any, 0, len(rates))
+	for _, rate := range rates {
+		rows = append(rows, []any{t.bindDate(rate.Publication), rate.Rate.String()})
+	}
+	return t.loadRows(ctx, db, rows, batchSize)
+}
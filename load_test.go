@@ -0,0 +1,138 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/tgulacsi/mnbarf/mnb"
+)
+
+func openTestDB(t *testing.T) (*sql.DB, sqlTable) {
+	t.Helper()
+	tbl, err := sqlOptions{Dialect: "sqlite"}.ratesTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := sql.Open(tbl.driverName(), filepath.Join(t.TempDir(), "mnb.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if _, err := db.Exec(`CREATE TABLE mnb_rates (
+  day TEXT NOT NULL,
+  currency TEXT NOT NULL,
+  unit INTEGER NOT NULL CHECK (unit > 0),
+  rate NUMERIC NOT NULL,
+  PRIMARY KEY (day, currency)
+)`); err != nil {
+		t.Fatal(err)
+	}
+	return db, tbl
+}
+
+func dumpRates(t *testing.T, db *sql.DB) []string {
+	t.Helper()
+	rows, err := db.Query("SELECT day, currency, unit, rate FROM mnb_rates ORDER BY day, currency")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var got []string
+	for rows.Next() {
+		var day, currency, unit, rate any
+		if err := rows.Scan(&day, &currency, &unit, &rate); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, canonicalSQLValue(day)+" "+canonicalSQLValue(currency)+" "+
+			canonicalSQLValue(unit)+" "+canonicalSQLValue(rate))
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return got
+}
+
+func TestLoadDayRates(t *testing.T) {
+	ctx := context.Background()
+	db, tbl := openTestDB(t)
+	days := []mnb.DayRates{
+		testDay(t, "2024-05-02", testRate(t, "EUR", 1, "392.00"), testRate(t, "JPY", 100, "235.10")),
+		testDay(t, "2024-05-03", testRate(t, "EUR", 1, "390.50"), testRate(t, "JPY", 100, "236.50")),
+	}
+	// batches of 3 rows, to cross the day boundary
+	stats, err := loadDayRates(ctx, db, tbl, days, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (loadStats{Inserted: 4}); stats != want {
+		t.Errorf("first load: got %v, wanted %v", stats, want)
+	}
+
+	days[1].Rates[0] = testRate(t, "EUR", 1, "391.25")
+	if stats, err = loadDayRates(ctx, db, tbl, days, 3); err != nil {
+		t.Fatal(err)
+	}
+	if want := (loadStats{Updated: 1, Unchanged: 3}); stats != want {
+		t.Errorf("second load: got %v, wanted %v", stats, want)
+	}
+
+	want := []string{
+		"2024-05-02 EUR 1 392",
+		"2024-05-02 JPY 100 235.1",
+		"2024-05-03 EUR 1 391.25",
+		"2024-05-03 JPY 100 236.5",
+	}
+	if got := dumpRates(t, db); !slices.Equal(got, want) {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
+
+func TestLoadDayRatesRollback(t *testing.T) {
+	ctx := context.Background()
+	db, tbl := openTestDB(t)
+	if _, err := loadDayRates(ctx, db, tbl, []mnb.DayRates{
+		testDay(t, "2024-05-02", testRate(t, "EUR", 1, "392.00")),
+	}, 0); err != nil {
+		t.Fatal(err)
+	}
+	before := dumpRates(t, db)
+
+	// an update and an insert, then a row violating the CHECK constraint
+	_, err := loadDayRates(ctx, db, tbl, []mnb.DayRates{
+		testDay(t, "2024-05-02", testRate(t, "EUR", 1, "393.00")),
+		testDay(t, "2024-05-03", testRate(t, "EUR", 1, "390.50"), testRate(t, "USD", 0, "365.00")),
+	}, 1)
+	if err == nil {
+		t.Fatal("wanted an error for unit=0")
+	}
+	if got := dumpRates(t, db); !slices.Equal(got, before) {
+		t.Errorf("not rolled back: got %q, wanted %q", got, before)
+	}
+}
+
+func TestLoadDayRatesRepeatedKey(t *testing.T) {
+	ctx := context.Background()
+	db, tbl := openTestDB(t)
+	// the same key twice in a batch must not make the multi-row upsert fail
+	stats, err := loadDayRates(ctx, db, tbl, []mnb.DayRates{
+		testDay(t, "2024-05-03", testRate(t, "EUR", 1, "390.50")),
+		testDay(t, "2024-05-03", testRate(t, "EUR", 1, "390.75"), testRate(t, "USD", 1, "364.10")),
+	}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (loadStats{Inserted: 2, Updated: 1}); stats != want {
+		t.Errorf("got %v, wanted %v", stats, want)
+	}
+	want := []string{"2024-05-03 EUR 1 390.75", "2024-05-03 USD 1 364.1"}
+	if got := dumpRates(t, db); !slices.Equal(got, want) {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
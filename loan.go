@@ -0,0 +1,94 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/tgulacsi/mnbarf/mnb"
+)
+
+// parseProjection parses the base rate projection: a comma separated list of day=rate pairs,
+// such as 2026-12-01=6,2027-06-01=5.5.
+func parseProjection(s string) ([]mnb.MNBBaseRate, error) {
+	var rates []mnb.MNBBaseRate
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+		dayS, rateS, ok := strings.Cut(part, "=")
+		if !ok {
+			return rates, fmt.Errorf("%q: day=rate is needed", part)
+		}
+		var r mnb.MNBBaseRate
+		if err := r.Publication.UnmarshalText([]byte(strings.TrimSpace(dayS))); err != nil {
+			return rates, fmt.Errorf("%q: %w", part, err)
+		}
+		if err := r.Rate.UnmarshalText([]byte(strings.TrimSpace(rateS))); err != nil {
+			return rates, fmt.Errorf("%q: %w", part, err)
+		}
+		rates = append(rates, r)
+	}
+	return rates, nil
+}
+
+// printLoanSchedule prints the loan schedule in the output format:
+// json and the templates get the whole schedule (the "row" template each period),
+// csv, html and markdown the periods.
+func printLoanSchedule(w io.Writer, sched mnb.LoanSchedule, outFormat string) error {
+	bw := bufio.NewWriter(w)
+	defer bw.Flush()
+
+	switch outFormat {
+	case "json":
+		enc := json.NewEncoder(bw)
+		enc.SetIndent("", "  ")
+		return enc.Encode(sched)
+
+	case "csv", "html", "markdown", "md":
+		t := table{
+			Title: fmt.Sprintf("%s loan schedule: %s HUF at base rate + %s%%, %d %sly payments",
+				sched.Amortization, outLocale.FormatDouble(sched.Principal), outLocale.FormatDouble(sched.Margin),
+				sched.Term, sched.Frequency),
+			Columns: []string{"n", "start", "end", "base_rate", "projected", "rate",
+				"opening", "interest", "principal", "payment", "closing"},
+		}
+		for _, p := range sched.Periods {
+			t.Rows = append(t.Rows, []string{
+				strconv.Itoa(p.N), outLocale.FormatDate(p.Start), outLocale.FormatDate(p.End),
+				outLocale.FormatDouble(p.BaseRate), strconv.FormatBool(p.Projected), outLocale.FormatDouble(p.Rate),
+				outLocale.FormatDouble(p.Opening), outLocale.FormatDouble(p.Interest), outLocale.FormatDouble(p.Principal),
+				outLocale.FormatDouble(p.Payment), outLocale.FormatDouble(p.Closing),
+			})
+		}
+		switch outFormat {
+		case "csv":
+			return t.WriteCSV(bw)
+		case "html":
+			return t.WriteHTML(bw)
+		}
+		return t.WriteMarkdown(bw)
+
+	case "sql":
+		return fmt.Errorf("the sql format is not supported for loan schedules")
+
+	default: // template
+		tmpl, err := parseOutTemplate(outFormat)
+		if err != nil {
+			logger.Info("template parse", "error", err)
+			return err
+		}
+		if err := executeOutTemplate(bw, tmpl, sched, sched.Periods); err != nil {
+			logger.Info("template execute", "error", err)
+			return err
+		}
+	}
+	return nil
+}
@@ -21,14 +21,22 @@ package main
 import (
 	"bufio"
 	"context"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log/slog"
+	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 	"syscall"
-	"text/template"
 	"time"
 
 	"github.com/UNO-SOFT/zlog/v2"
@@ -41,6 +49,9 @@ var logger = zlog.NewLogger(zlog.MaybeConsoleHandler(&verbose, os.Stderr)).SLog(
 
 func main() {
 	if err := Main(); err != nil {
+		if errors.Is(err, errAlertsFired) {
+			os.Exit(2)
+		}
 		logger.Error("ERROR", "error", err)
 		os.Exit(1)
 	}
@@ -50,9 +61,15 @@ func Main() error {
 	var wsC mnb.MNBArfolyamService
 	var wsR mnb.MNBAlapkamatService
 	fs := flag.NewFlagSet("mnbarf", flag.ContinueOnError)
-	flagOutFormat := fs.String("format", "csv", `output format (possible: csv, json or template (go template: you can use Day, Currency, Unit and Rate - i.e. {{.Day}},{{.Currency}},{{.Unit}},{{.Rate}}{{print "\n"}})`)
+	flagOutFormat := fs.String("format", "csv", `output format (possible: csv, json, sql, html, markdown, @file.tmpl or template (go template: you can use Day, Currency, Unit and Rate - i.e. {{.Day}},{{.Currency}},{{.Unit}},{{.Rate}}{{print "\n"}})`)
 	fs.Var(&verbose, "v", "verbose logging")
 	flagURL := fs.String("url", "", "URL to use")
+	flagLocale := fs.String("locale", "", "format numbers and dates according to the locale (hu, en, de ...)")
+	flagFill := fs.Bool("fill", false, "rates for every calendar day of the period, carrying the last published rates forward")
+	fs.StringVar(&sqlOpts.Dialect, "sql-dialect", sqlOpts.Dialect, "SQL dialect for -format=sql (postgres, oracle or sqlite)")
+	fs.StringVar(&sqlOpts.Table, "sql-table", "", "table name for -format=sql (default "+defaultRatesTable+" or "+defaultBaseRatesTable+")")
+	fs.StringVar(&sqlOpts.Columns, "sql-columns", "", "column names for -format=sql, as name=column pairs (names: day, currency, unit, rate)")
+	fs.BoolVar(&sqlOpts.Upsert, "sql-upsert", false, "generate ON CONFLICT (MERGE for oracle) upserts instead of plain INSERTs")
 
 	baserateCmd := ffcli.Command{
 		Name: "baserate",
@@ -72,7 +89,7 @@ func Main() error {
 					return err
 				}
 				//Log("msg","GetCentralBankBaseRates", "begin", begin, "end", end, "rates", rates)
-				return printBaseRates(rates, *flagOutFormat)
+				return printBaseRates(os.Stdout, rates, *flagOutFormat)
 			}
 			rate, err := wsR.GetCurrentBaseRate(ctx)
 			if err != nil {
@@ -80,7 +97,7 @@ func Main() error {
 				return err
 			}
 			//Log("msg","GetCurrentCentralBankBaseRate", "rate", rate)
-			fmt.Println(rate.Publication, rate.Rate)
+			fmt.Println(outLocale.FormatDate(rate.Publication), outLocale.FormatDouble(rate.Rate))
 			return nil
 		},
 	}
@@ -111,12 +128,19 @@ func Main() error {
 			if err != nil {
 				return err
 			}
+			if *flagFill {
+				filled, err := wsC.GetFilledExchangeRates(ctx, begin, end, args[2:]...)
+				if err != nil {
+					return err
+				}
+				return printFilledDayRates(os.Stdout, filled, *flagOutFormat)
+			}
 			dayRates, err := wsC.GetExchangeRates(ctx, begin, end, args[2:]...)
 			if err != nil {
 				logger.Info("GetExchangeRates", "error", err)
 			}
 			//Log("msg","GetExchangeRates", "dayRates", dayRates)
-			if printErr := printDayRates(dayRates, *flagOutFormat); printErr != nil && err == nil {
+			if printErr := printDayRates(os.Stdout, dayRates, *flagOutFormat); printErr != nil && err == nil {
 				err = printErr
 			}
 			return err
@@ -131,7 +155,7 @@ func Main() error {
 				logger.Info("GetCurrentExchangeRates", "error", err)
 			}
 			//Log("msg","GetCurrentExchangeRates", "day", day.Day, "rates", day.Rates)
-			if printErr := printDayRates([]mnb.DayRates{day}, *flagOutFormat); printErr != nil && err == nil {
+			if printErr := printDayRates(os.Stdout, []mnb.DayRates{day}, *flagOutFormat); printErr != nil && err == nil {
 				err = printErr
 			}
 			return err
@@ -153,6 +177,590 @@ func Main() error {
 		},
 	}
 
+	loadFs := flag.NewFlagSet("load", flag.ContinueOnError)
+	flagLoadDSN := loadFs.String("dsn", "", "database to load the rates into (the driver is chosen by -sql-dialect)")
+	flagLoadBatch := loadFs.Int("batch", 1000, "batch size (rows per upsert statement)")
+	flagLoadBaseRate := loadFs.Bool("baserate", false, "load base rates instead of exchange rates")
+	loadCmd := ffcli.Command{
+		Name:       "load",
+		ShortUsage: "load -dsn=<dsn> [-batch=1000] <begin> <end> <currency>... | load -dsn=<dsn> -baserate [<begin> [<end>]]",
+		FlagSet:    loadFs,
+		Exec: func(ctx context.Context, args []string) error {
+			if *flagLoadDSN == "" {
+				return fmt.Errorf("-dsn is required")
+			}
+			var t sqlTable
+			var err error
+			if *flagLoadBaseRate {
+				t, err = sqlOpts.baseRatesTable()
+			} else {
+				if len(args) < 3 {
+					return fmt.Errorf("begin, end and at least one currency is needed")
+				}
+				t, err = sqlOpts.ratesTable()
+			}
+			if err != nil {
+				return err
+			}
+			for len(args) < 2 {
+				args = append(args, "")
+			}
+			begin, end, err := parseDates(args[0], args[1])
+			if err != nil {
+				return err
+			}
+			db, err := t.open(*flagLoadDSN)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			var stats loadStats
+			if *flagLoadBaseRate {
+				rates, err := wsR.GetBaseRates(ctx, begin, end)
+				if err != nil {
+					logger.Info("GetCentralBankBaseRates", "begin", begin, "end", end, "error", err)
+					return err
+				}
+				if stats, err = loadBaseRates(ctx, db, t, rates, *flagLoadBatch); err != nil {
+					return err
+				}
+			} else {
+				dayRates, err := wsC.GetExchangeRates(ctx, begin, end, args[2:]...)
+				if err != nil {
+					logger.Info("GetExchangeRates", "error", err)
+					return err
+				}
+				if stats, err = loadDayRates(ctx, db, t, dayRates, *flagLoadBatch); err != nil {
+					return err
+				}
+			}
+			logger.Info("loaded", "table", t.Name, "inserted", stats.Inserted, "updated", stats.Updated, "unchanged", stats.Unchanged)
+			fmt.Println(stats)
+			return nil
+		},
+	}
+
+	serveFs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	flagServeAddr := serveFs.String("addr", ":8080", "address to listen on")
+	flagServeCacheTTL := serveFs.Duration("cache-ttl", 10*time.Minute, "cache the MNB answers for this long")
+	serveCmd := ffcli.Command{
+		Name:       "serve",
+		ShortUsage: "serve [-addr=:8080] [-cache-ttl=10m]",
+		FlagSet:    serveFs,
+		Exec: func(ctx context.Context, args []string) error {
+			if *flagServeCacheTTL > 0 {
+				cache := mnb.NewMemoryCache(*flagServeCacheTTL)
+				wsC.Cache, wsR.Cache = cache, cache
+			}
+			return listenAndServe(ctx, *flagServeAddr, newAPIServer(wsC, wsR))
+		},
+	}
+
+	proxyFs := flag.NewFlagSet("proxy", flag.ContinueOnError)
+	flagProxyAddr := proxyFs.String("addr", ":8081", "address to listen on")
+	flagProxyCacheTTL := proxyFs.Duration("cache-ttl", 10*time.Minute, "answer the same requests from the cache for this long")
+	flagProxyStore := proxyFs.String("store", "", "directory to store the last good answers in, for MNB outages (default: in memory)")
+	proxyCmd := ffcli.Command{
+		Name:       "proxy",
+		ShortUsage: "proxy [-addr=:8081] [-cache-ttl=10m] [-store=<dir>]",
+		FlagSet:    proxyFs,
+		Exec: func(ctx context.Context, args []string) error {
+			p := soapProxy{m: wsC.MNB}
+			if *flagProxyCacheTTL > 0 {
+				p.m.Cache = mnb.NewMemoryCache(*flagProxyCacheTTL)
+			}
+			if *flagProxyStore == "" {
+				p.stale = mnb.NewMemoryCache(365 * 24 * time.Hour)
+			} else {
+				if err := os.MkdirAll(*flagProxyStore, 0750); err != nil {
+					return err
+				}
+				p.stale = mnb.DirCache{Dir: *flagProxyStore}
+			}
+			return listenAndServe(ctx, *flagProxyAddr, p)
+		},
+	}
+
+	exporterFs := flag.NewFlagSet("exporter", flag.ContinueOnError)
+	flagExporterAddr := exporterFs.String("addr", ":9488", "address to listen on")
+	flagExporterInterval := exporterFs.Duration("interval", 10*time.Minute, "polling interval (positive)")
+	exporterCmd := ffcli.Command{
+		Name:       "exporter",
+		ShortUsage: "exporter [-addr=:9488] [-interval=10m]",
+		FlagSet:    exporterFs,
+		Exec: func(ctx context.Context, args []string) error {
+			if *flagExporterInterval <= 0 {
+				return fmt.Errorf("interval=%s: must be positive", *flagExporterInterval)
+			}
+			e := newExporter(wsC, wsR)
+			go e.Run(ctx, *flagExporterInterval)
+			mux := http.NewServeMux()
+			mux.Handle("GET /metrics", e.Handler())
+			return listenAndServe(ctx, *flagExporterAddr, mux)
+		},
+	}
+
+	grpcFs := flag.NewFlagSet("grpc", flag.ContinueOnError)
+	flagGRPCAddr := grpcFs.String("addr", ":9090", "address to listen on")
+	flagGRPCCacheTTL := grpcFs.Duration("cache-ttl", 10*time.Minute, "cache the MNB answers for this long")
+	grpcCmd := ffcli.Command{
+		Name:       "grpc",
+		ShortUsage: "grpc [-addr=:9090] [-cache-ttl=10m]",
+		FlagSet:    grpcFs,
+		Exec: func(ctx context.Context, args []string) error {
+			if *flagGRPCCacheTTL > 0 {
+				cache := mnb.NewMemoryCache(*flagGRPCCacheTTL)
+				wsC.Cache, wsR.Cache = cache, cache
+			}
+			lis, err := net.Listen("tcp", *flagGRPCAddr)
+			if err != nil {
+				return err
+			}
+			return serveGRPC(ctx, lis, wsC, wsR)
+		},
+	}
+
+	statsFs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	flagStatsPeriod := statsFs.String("period", "month", "resample to this period: week, month, quarter or year")
+	statsCmd := ffcli.Command{
+		Name:       "stats",
+		ShortUsage: "stats [-period=month] <begin> <end> <currency>...",
+		FlagSet:    statsFs,
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) < 3 {
+				return fmt.Errorf("begin, end and at least one currency is needed")
+			}
+			period, err := mnb.ParsePeriod(*flagStatsPeriod)
+			if err != nil {
+				return err
+			}
+			begin, end, err := parseDates(args[0], args[1])
+			if err != nil {
+				return err
+			}
+			days, err := fetchRates(ctx, wsC, begin, end, args[2:])
+			if err != nil {
+				return err
+			}
+			aggs, err := mnb.Resample(days, period)
+			if err != nil {
+				return err
+			}
+			return printAggregates(os.Stdout, aggs, period, *flagOutFormat)
+		},
+	}
+
+	analyzeFs := flag.NewFlagSet("analyze", flag.ContinueOnError)
+	flagAnalyzePeriod := analyzeFs.String("period", "", "returns of this period: week, month, quarter or year (default: the publication days)")
+	flagAnalyzeWindow := analyzeFs.Int("window", 20, "number of returns in the rolling volatility window")
+	flagAnalyzeShow := analyzeFs.String("show", "summary", "table to print in the csv, html and markdown formats: "+strings.Join(analysisTables, ", "))
+	analyzeCmd := ffcli.Command{
+		Name:       "analyze",
+		ShortUsage: "analyze [-period=] [-window=20] [-show=summary] <begin> <end> <currency>...",
+		FlagSet:    analyzeFs,
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) < 3 {
+				return fmt.Errorf("begin, end and at least one currency is needed")
+			}
+			if !slices.Contains(analysisTables, *flagAnalyzeShow) {
+				return fmt.Errorf("unknown -show=%q (one of %q is accepted)", *flagAnalyzeShow, analysisTables)
+			}
+			var period mnb.Period
+			if *flagAnalyzePeriod != "" {
+				var err error
+				if period, err = mnb.ParsePeriod(*flagAnalyzePeriod); err != nil {
+					return err
+				}
+			}
+			begin, end, err := parseDates(args[0], args[1])
+			if err != nil {
+				return err
+			}
+			days, err := fetchRates(ctx, wsC, begin, end, args[2:])
+			if err != nil {
+				return err
+			}
+			var report analysisReport
+			report.Analyses, report.Correlation = mnb.Analyze(days, period, *flagAnalyzeWindow)
+			return printAnalysis(os.Stdout, report, *flagAnalyzeShow, *flagOutFormat)
+		},
+	}
+
+	revalueFs := flag.NewFlagSet("revalue", flag.ContinueOnError)
+	flagRevalueDay := revalueFs.String("day", "", "revaluation day (default: today)")
+	flagRevaluePlaces := revalueFs.Int("round", 2, "round the HUF values to this many decimal places")
+	flagRevalueTotals := revalueFs.Bool("totals", false, "print the per currency totals instead of the items (csv, html and markdown)")
+	revalueCmd := ffcli.Command{
+		Name:       "revalue",
+		ShortUsage: "revalue [-day=2006-01-02] [-round=2] [-totals] <open-items.csv|->",
+		FlagSet:    revalueFs,
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("the open items CSV file is needed")
+			}
+			day := time.Now()
+			if *flagRevalueDay != "" {
+				var err error
+				if day, err = time.Parse("2006-01-02", *flagRevalueDay); err != nil {
+					return fmt.Errorf("day=%q: %w", *flagRevalueDay, err)
+				}
+			}
+			r, err := openInput(args[0])
+			if err != nil {
+				return err
+			}
+			defer r.Close()
+			items, err := readOpenItems(r)
+			if err != nil {
+				return fmt.Errorf("%s: %w", args[0], err)
+			}
+			report, err := wsC.Revalue(ctx, items, day, int32(*flagRevaluePlaces))
+			if err != nil {
+				return err
+			}
+			return printRevaluation(os.Stdout, report, *flagRevalueTotals, *flagOutFormat)
+		},
+	}
+
+	realizeFs := flag.NewFlagSet("realize", flag.ContinueOnError)
+	flagRealizeMethod := realizeFs.String("method", "fifo", "match the outflows to the inflows by fifo or (weighted) average cost")
+	flagRealizePlaces := realizeFs.Int("round", 2, "round the HUF values to this many decimal places")
+	flagRealizeTotals := realizeFs.Bool("totals", false, "print the per currency totals instead of the flows (csv, html and markdown)")
+	realizeCmd := ffcli.Command{
+		Name:       "realize",
+		ShortUsage: "realize [-method=fifo|average] [-round=2] [-totals] <ledger.csv|->",
+		FlagSet:    realizeFs,
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("the ledger CSV file is needed")
+			}
+			method, err := mnb.ParseCostMethod(*flagRealizeMethod)
+			if err != nil {
+				return err
+			}
+			r, err := openInput(args[0])
+			if err != nil {
+				return err
+			}
+			defer r.Close()
+			flows, err := readFlows(r)
+			if err != nil {
+				return fmt.Errorf("%s: %w", args[0], err)
+			}
+			report, err := wsC.Realize(ctx, flows, method, int32(*flagRealizePlaces))
+			if err != nil {
+				return err
+			}
+			return printRealization(os.Stdout, report, *flagRealizeTotals, *flagOutFormat)
+		},
+	}
+
+	loanFs := flag.NewFlagSet("loan", flag.ContinueOnError)
+	flagLoanPrincipal := loanFs.String("principal", "", "the principal in HUF")
+	flagLoanMargin := loanFs.String("margin", "0", "the margin over the base rate, in percent")
+	flagLoanStart := loanFs.String("start", "", "the day of the disbursement (default: today)")
+	flagLoanFrequency := loanFs.String("frequency", "month", "payment frequency: month, quarter or year")
+	flagLoanTerm := loanFs.Int("term", 0, "the number of payments")
+	flagLoanAmortization := loanFs.String("amortization", "annuity", "annuity or linear")
+	flagLoanProjection := loanFs.String("projection", "", "projected base rates after the last publication, as day=rate pairs: 2026-12-01=6,2027-06-01=5.5")
+	flagLoanPlaces := loanFs.Int("round", 0, "round the HUF amounts to this many decimal places")
+	loanCmd := ffcli.Command{
+		Name:       "loan",
+		ShortUsage: "loan -principal=<HUF> -margin=<percent> -term=<payments> [-start=2006-01-02] [-frequency=month|quarter|year] [-amortization=annuity|linear] [-projection=2026-12-01=6,...]",
+		FlagSet:    loanFs,
+		Exec: func(ctx context.Context, args []string) error {
+			loan := mnb.Loan{Start: mnb.Date(time.Now()), Term: *flagLoanTerm}
+			if err := loan.Principal.UnmarshalText([]byte(*flagLoanPrincipal)); err != nil {
+				return fmt.Errorf("principal=%q: %w", *flagLoanPrincipal, err)
+			}
+			if err := loan.Margin.UnmarshalText([]byte(*flagLoanMargin)); err != nil {
+				return fmt.Errorf("margin=%q: %w", *flagLoanMargin, err)
+			}
+			if *flagLoanStart != "" {
+				if err := loan.Start.UnmarshalText([]byte(*flagLoanStart)); err != nil {
+					return fmt.Errorf("start=%q: %w", *flagLoanStart, err)
+				}
+			}
+			var err error
+			if loan.Frequency, err = mnb.ParseLoanFrequency(*flagLoanFrequency); err != nil {
+				return err
+			}
+			if loan.Amortization, err = mnb.ParseAmortization(*flagLoanAmortization); err != nil {
+				return err
+			}
+			projection, err := parseProjection(*flagLoanProjection)
+			if err != nil {
+				return fmt.Errorf("projection: %w", err)
+			}
+			sched, err := wsR.LoanSchedule(ctx, loan, projection, int32(*flagLoanPlaces))
+			if err != nil {
+				return err
+			}
+			return printLoanSchedule(os.Stdout, sched, *flagOutFormat)
+		},
+	}
+
+	enrichFs := flag.NewFlagSet("enrich", flag.ContinueOnError)
+	flagEnrichIn := enrichFs.String("in", "auto", "input format: csv, ndjson or auto (by the first character)")
+	flagEnrichDate := enrichFs.String("date", "date", "name of the date column")
+	flagEnrichAmount := enrichFs.String("amount", "amount", "name of the amount column")
+	flagEnrichCurrency := enrichFs.String("currency", "currency", "name of the currency column")
+	flagEnrichTo := enrichFs.String("to", "HUF", "convert to this currency")
+	flagEnrichPlaces := enrichFs.Int("round", 2, "round the converted amounts to this many decimal places")
+	enrichCmd := ffcli.Command{
+		Name:       "enrich",
+		ShortUsage: "enrich [-in=auto|csv|ndjson] [-date=date] [-amount=amount] [-currency=currency] [-to=HUF] [-round=2] [<input>|-]",
+		FlagSet:    enrichFs,
+		Exec: func(ctx context.Context, args []string) error {
+			fn := "-"
+			if len(args) != 0 {
+				fn = args[0]
+			}
+			r, err := openInput(fn)
+			if err != nil {
+				return err
+			}
+			defer r.Close()
+			e := enricher{
+				DateCol: *flagEnrichDate, AmountCol: *flagEnrichAmount, CurrencyCol: *flagEnrichCurrency,
+				To: strings.ToUpper(*flagEnrichTo), Places: int32(*flagEnrichPlaces),
+			}
+			if err := e.Enrich(ctx, wsC, r, os.Stdout, *flagEnrichIn); err != nil {
+				return fmt.Errorf("%s: %w", fn, err)
+			}
+			return nil
+		},
+	}
+
+	calcFs := flag.NewFlagSet("calc", flag.ContinueOnError)
+	flagCalcPlaces := calcFs.Int("round", 2, "round the result to this many decimal places")
+	calcCmd := ffcli.Command{
+		Name:       "calc",
+		ShortUsage: "calc [-round=2] <expression>",
+		FlagSet:    calcFs,
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("the expression is needed")
+			}
+			res, err := calc(ctx, wsC, strings.Join(args, " "), int32(*flagCalcPlaces))
+			if err != nil {
+				return err
+			}
+			return printCalc(os.Stdout, res, *flagOutFormat)
+		},
+	}
+
+	invoiceRateFs := flag.NewFlagSet("invoice-rate", flag.ContinueOnError)
+	flagInvoiceRateDay := invoiceRateFs.String("day", "", "the day of supply (default: today)")
+	flagInvoiceRateECBURL := invoiceRateFs.String("ecb-url", "", "ECB reference rates feed URL (default: the 90 days or the whole history feed, by the day)")
+	invoiceRateCmd := ffcli.Command{
+		Name:       "invoice-rate",
+		ShortUsage: "invoice-rate [-day=2006-01-02] [-ecb-url=...] <currency>",
+		FlagSet:    invoiceRateFs,
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("the currency is needed")
+			}
+			now := time.Now()
+			day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+			if *flagInvoiceRateDay != "" {
+				var err error
+				if day, err = parseDay(*flagInvoiceRateDay); err != nil {
+					return err
+				}
+			}
+			ecb := mnb.NewECB(*flagInvoiceRateECBURL, wsC.Client, wsC.Logger)
+			rates, err := wsC.InvoiceRates(ctx, ecb, strings.ToUpper(args[0]), day)
+			if len(rates) == 0 {
+				return err
+			}
+			if err != nil {
+				logger.Warn("invoice-rate", "error", err)
+			}
+			return printInvoiceRates(os.Stdout, rates, *flagOutFormat)
+		},
+	}
+
+	siteFs := flag.NewFlagSet("site", flag.ContinueOnError)
+	flagSiteFrom := siteFs.String("from", "", "first day (default: the start of the year, four years ago)")
+	flagSiteTo := siteFs.String("to", "", "last day (default: today)")
+	flagSiteCurrencies := siteFs.String("currencies", "", "comma separated list of the currencies (default: the currently published ones)")
+	flagSiteStore := siteFs.String("store", "", "read the rates from this daemon -state directory instead of MNB")
+	flagSiteBaseRate := siteFs.Bool("baserate", true, "generate the base rate history page")
+	siteCmd := ffcli.Command{
+		Name:       "site",
+		ShortUsage: "site [-from=2006-01-02] [-to=2006-01-02] [-currencies=EUR,USD] [-store=<dir>] <outdir>",
+		FlagSet:    siteFs,
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("the output directory is needed")
+			}
+			now := time.Now()
+			begin, end := time.Date(now.Year()-4, 1, 1, 0, 0, 0, 0, time.UTC), now
+			var err error
+			if *flagSiteFrom != "" {
+				if begin, err = time.Parse("2006-01-02", *flagSiteFrom); err != nil {
+					return fmt.Errorf("from=%q: %w", *flagSiteFrom, err)
+				}
+			}
+			if *flagSiteTo != "" {
+				if end, err = time.Parse("2006-01-02", *flagSiteTo); err != nil {
+					return fmt.Errorf("to=%q: %w", *flagSiteTo, err)
+				}
+			}
+			var currencies []string
+			for _, c := range strings.Split(*flagSiteCurrencies, ",") {
+				if c = strings.ToUpper(strings.TrimSpace(c)); c != "" {
+					currencies = append(currencies, c)
+				}
+			}
+			st := site{Dir: args[0]}
+			if *flagSiteStore != "" {
+				st.Days, err = readStoredRates(*flagSiteStore, begin, end, currencies)
+			} else {
+				if len(currencies) == 0 {
+					current, err := wsC.GetCurrentExchangeRates(ctx)
+					if err != nil {
+						return err
+					}
+					for _, r := range current.Rates {
+						currencies = append(currencies, r.Currency)
+					}
+				}
+				st.Days, err = fetchRates(ctx, wsC, begin, end, currencies)
+			}
+			if err != nil {
+				return err
+			}
+			if *flagSiteBaseRate {
+				if st.BaseRates, err = fetchBaseRates(ctx, wsR, begin, end); err != nil {
+					return err
+				}
+			}
+			return st.Generate()
+		},
+	}
+
+	alertFs := flag.NewFlagSet("alert", flag.ContinueOnError)
+	flagAlertRules := alertFs.String("rules", "alerts.json", "the alert rules (JSON)")
+	flagAlertDay := alertFs.String("day", "", "evaluate the rules on this day's rates (default: the current rates)")
+	alertHooks := hookFlags(alertFs)
+	alertCmd := ffcli.Command{
+		Name:       "alert",
+		ShortUsage: "alert [-rules=alerts.json] [-day=2006-01-02] [-exec=<command>] [-webhook=<url>]",
+		FlagSet:    alertFs,
+		Exec: func(ctx context.Context, args []string) error {
+			rules, err := readAlertRules(*flagAlertRules)
+			if err != nil {
+				return err
+			}
+			var day mnb.DayRates
+			if *flagAlertDay == "" {
+				day, err = wsC.GetCurrentExchangeRates(ctx)
+			} else {
+				var t time.Time
+				if t, err = time.Parse("2006-01-02", *flagAlertDay); err != nil {
+					return fmt.Errorf("day=%q: %w", *flagAlertDay, err)
+				}
+				currencies := alertCurrencies(rules)
+				if len(currencies) == 0 {
+					// only base rate rules: the rates are needed for the publication days
+					var current mnb.DayRates
+					if current, err = wsC.GetCurrentExchangeRates(ctx); err != nil {
+						return err
+					}
+					for _, r := range current.Rates {
+						currencies = append(currencies, r.Currency)
+					}
+				}
+				day, err = wsC.GetExchangeRatesAt(ctx, t, currencies...)
+			}
+			if err != nil {
+				return err
+			}
+			// the alerts of the other rules are reported even if some rules failed
+			alerts, evalErr := checkAlerts(ctx, wsC, wsR, rules, day)
+			if err := reportAlerts(ctx, alertHooks(""), alerts); err != nil {
+				return errors.Join(evalErr, err)
+			}
+			if evalErr != nil {
+				return evalErr
+			}
+			if len(alerts) != 0 {
+				return errAlertsFired
+			}
+			return nil
+		},
+	}
+
+	daemonFs := flag.NewFlagSet("daemon", flag.ContinueOnError)
+	flagDaemonState := daemonFs.String("state", "", "directory of the received rates and the last day (default: <user cache dir>/mnbarf)")
+	flagDaemonPublishAt := daemonFs.String("publish-at", "11:45", "start polling at this time of the day (Budapest time)")
+	flagDaemonPollFor := daemonFs.Duration("poll-for", 4*time.Hour, "poll for this long after -publish-at")
+	flagDaemonPollInterval := daemonFs.Duration("poll-interval", 5*time.Minute, "wait between the polls")
+	flagDaemonMaxBackoff := daemonFs.Duration("max-backoff", 30*time.Minute, "maximum wait after errors")
+	flagDaemonWriteDir := daemonFs.String("write-dir", "", "write the new rates into this directory, in -format")
+	flagDaemonDSN := daemonFs.String("dsn", "", "load the new rates into this database (see load)")
+	flagDaemonAlerts := daemonFs.String("alerts", "", "evaluate the alert rules of this file on the new rates (see alert)")
+	daemonHooks := hookFlags(daemonFs)
+	daemonCmd := ffcli.Command{
+		Name:       "daemon",
+		ShortUsage: "daemon [-state=<dir>] [-publish-at=11:45] [-write-dir=<dir>] [-dsn=<dsn>] [-exec=<command>] [-webhook=<url>]",
+		FlagSet:    daemonFs,
+		Exec: func(ctx context.Context, args []string) error {
+			publishAt, err := time.Parse("15:04", *flagDaemonPublishAt)
+			if err != nil {
+				return fmt.Errorf("publish-at=%q: %w", *flagDaemonPublishAt, err)
+			}
+			d := daemon{
+				wsC:          wsC,
+				wsR:          wsR,
+				PublishAt:    time.Duration(publishAt.Hour())*time.Hour + time.Duration(publishAt.Minute())*time.Minute,
+				PollFor:      *flagDaemonPollFor,
+				PollInterval: *flagDaemonPollInterval,
+				MaxBackoff:   *flagDaemonMaxBackoff,
+				StateDir:     *flagDaemonState,
+				Calendar:     mnb.DefaultCalendar,
+			}
+			if d.StateDir == "" {
+				dir, err := os.UserCacheDir()
+				if err != nil {
+					return err
+				}
+				d.StateDir = filepath.Join(dir, "mnbarf")
+			}
+			if *flagDaemonWriteDir != "" {
+				d.Actions = append(d.Actions, fileAction(*flagDaemonWriteDir, *flagOutFormat))
+			}
+			if *flagDaemonDSN != "" {
+				t, err := sqlOpts.ratesTable()
+				if err != nil {
+					return err
+				}
+				db, err := t.open(*flagDaemonDSN)
+				if err != nil {
+					return err
+				}
+				defer db.Close()
+				d.Actions = append(d.Actions, daemonAction{Name: "load " + t.Name, Do: func(ctx context.Context, day mnb.DayRates) error {
+					stats, err := loadDayRates(ctx, db, t, []mnb.DayRates{day}, 0)
+					logger.Info("loaded", "table", t.Name, "day", day.Day, "stats", stats.String())
+					return err
+				}})
+			}
+			d.Hooks = daemonHooks(filepath.Join(d.StateDir, "dead-letter.jsonl"))
+			if *flagDaemonAlerts != "" {
+				rules, err := readAlertRules(*flagDaemonAlerts)
+				if err != nil {
+					return err
+				}
+				d.Actions = append(d.Actions, alertAction(wsC, wsR, rules, d.Hooks))
+			}
+			return d.Run(ctx)
+		},
+	}
+
 	app := ffcli.Command{FlagSet: fs,
 		LongHelp: `Usage: mnbarf [options] <command>
 
@@ -161,24 +769,168 @@ List all the possible currencies:
 
 Get the exchange rates for a specified period, for the specified currencies:
 	mnbarf [options: -format] range <currencies> [<first day> [<last day>]]
-The default first day is 30 days before yesterday,
-and the default last day is yesterday.
+The default period is the last 22 business days (of the Hungarian calendar, with
+the public holidays and moved working days) till yesterday;
+the default last day is yesterday.
 
 for example to get USD and EUR for all days in the history (till yesterday):
 	mnbarf -format=csv USD,EUR 1949-01-01
 
+With -fill, the rates command gives rates for every calendar day of the period:
+the days without publication get the last published rates, and each row
+has the source publication day (source column in csv, Source field in json and templates).
+
 Get the actual exchange rates, for all currencies - this is the defallt:
 	mnbarf [options: -format]
 
 Get the base rates:
 	mnbarf rates|baserate|kamat|alapkamat
 
+-locale formats the numbers and dates of the csv and template outputs
+	according to the CLDR conventions of the language (i.e. hu, en, de-AT);
+	without it, numbers have decimal dot and dates are in ISO 8601 format.
+
 -format awaits
-	csv for semicolon separated output in the column order of
-		day;currency;unit;rate
+	csv for comma separated output in the column order of
+		day,currency,unit,rate
+		(semicolon separated with a decimal comma -locale, such as hu)
 	json to output JSON with Day, Currency, Unit and Rate fields
+	html for a self-contained HTML report with a summary
+		(first, last, min, max, mean, change and a sparkline per currency)
+	markdown for the same report as a Markdown table
+	sql to output INSERT statements, see -sql-dialect, -sql-table,
+		-sql-columns and -sql-upsert
+	@file.tmpl to read a Go text/template file, which can define
+		"header" and "footer" templates (executed with all the days,
+		or all the base rates), and a "row" template (executed for each rate).
+		Without a "row" template, the whole file is executed once.
 	or anything else, which will be treated as a Go text/template,
-		with fields of Day, Currency, Unit and Rate.
+		with fields of Day, Currency, Unit and Rate
+		(and Date, Decimal, DayRates; base rates have Day, Date, Decimal, Publication and Rate).
+
+The templates can use the following functions:
+	add, sub, mul, div: decimal arithmetic, i.e. {{mul .Decimal 2}}
+	perUnit: the rate of one unit, i.e. {{perUnit .Decimal .Unit}}
+	round: round to the given decimal places, i.e. {{round 2 .Decimal}}
+	decimal: convert to decimal
+	date: format the date with a Go time layout, i.e. {{date "2006.01.02." .Date}}
+	hu: print the number with decimal comma, i.e. {{hu .Decimal}}
+	localNumber, localDate: format according to -locale, i.e. {{localNumber .Decimal}}
+
+for example to generate PostgreSQL upserts into the erp.fx_rates table:
+	mnbarf -format=sql -sql-upsert -sql-table=erp.fx_rates -sql-columns=day=rate_date rates 2024-01-01 2024-01-31 EUR USD
+
+Load (upsert) the exchange rates (or the base rates) directly into a database:
+	mnbarf [options: -sql-*] load -dsn=<dsn> <first day> <last day> <currencies>
+	mnbarf [options: -sql-*] load -dsn=<dsn> -baserate [<first day> [<last day>]]
+The driver is chosen by -sql-dialect (pgx for postgres, godror for oracle, sqlite for sqlite;
+the Oracle driver needs cgo, so it is only built with -tags=godror),
+and the inserted, updated and unchanged row counts are printed.
+
+Serve a JSON HTTP API (the OpenAPI document is at /openapi.json):
+	mnbarf serve [-addr=:8080] [-cache-ttl=10m]
+with a web UI at /ui/, and the endpoints
+	/rates?from=<first day>&to=<last day>&currency=<currency>[&format=json|csv|xlsx]
+	/rates/current
+	/convert?amount=<amount>&from=<currency>&to=<currency>[&date=<day>]
+	/baserate[?from=<first day>&to=<last day>]
+	/baserate/at/<day>
+	/currencies
+	/info
+	/feed/rates.atom[?days=30&currency=<currency>]
+	/feed/baserate.atom[?years=5]
+
+Act as a caching SOAP proxy for the clients of arfolyamok.asmx and alapkamat.asmx:
+	mnbarf [-url=<upstream>] proxy [-addr=:8081] [-cache-ttl=10m] [-store=<dir>]
+Repeated requests are answered from the cache, and if MNB is unreachable,
+with the last good answer from the store.
+
+Export the current exchange rates and base rate as Prometheus metrics on /metrics:
+	mnbarf exporter [-addr=:9488] [-interval=10m]
+with mnb_exchange_rate{currency,unit}, mnb_base_rate, mnb_publication_timestamp{kind}
+and the mnb_scrape_* health metrics of the calls.
+
+Serve the gRPC API (see mnbpb/mnb.proto):
+	mnbarf grpc [-addr=:9090] [-cache-ttl=10m]
+
+Print the mean, first, last, min and max rate (with their days), the count and the change
+of each currency in each week, month, quarter or year of the period:
+	mnbarf [options] stats [-period=month] <begin> <end> <currency>...
+
+Analyze the returns of the currencies (of the publication days, or of each -period):
+the total return, the mean and standard deviation of the log returns, the annualized volatility,
+the rolling volatility of -window returns and the correlation matrix of the log returns.
+The json format and the templates get everything, csv, html and markdown print the -show table
+(summary, returns, rolling or correlation):
+	mnbarf [options] analyze [-period=] [-window=20] [-show=summary] <begin> <end> <currency>...
+
+Revalue the open foreign currency items at the rates of -day (or the last publication day before it):
+the CSV (separated by comma, semicolon or tab) needs an id, currency and amount column,
+and either the booked HUF value (booked) or the booking day (booking_date) of each item.
+It prints the revalued HUF amount and the unrealized gain or loss of each item, or with -totals,
+of each currency:
+	mnbarf [options] revalue [-day=2006-01-02] [-round=2] [-totals] <open-items.csv|->
+
+Compute the realized FX gains and losses of a ledger of foreign currency flows: the CSV needs a date,
+currency and amount (positive for inflows, negative for outflows) column, and may have an id column.
+Each flow is valued at the rate of its day (or the last publication day before it), the outflows are
+matched to the inflows by -method=fifo or average cost; it prints each flow with its cost, realized
+gain or loss and the balance with its book value after it, or with -totals, the totals of each currency:
+	mnbarf [options] realize [-method=fifo|average] [-round=2] [-totals] <ledger.csv|->
+
+Compute the payment schedule of a variable-rate loan at the MNB base rate plus -margin:
+the interest rate of each period is set by the base rate in effect on its first day,
+from the history, and after the last publication, from the -projection (day=rate pairs);
+with -amortization=annuity, the equal payment is recomputed whenever the rate changes:
+	mnbarf [options] loan -principal=<HUF> -margin=<percent> -term=<payments> [-start=2006-01-02] \
+		[-frequency=month|quarter|year] [-amortization=annuity|linear] [-projection=2026-12-01=6,...] [-round=0]
+
+Enrich a CSV or NDJSON stream of records (such as invoices or bank statements) with the MNB rate,
+its publication day (the record's day, or the last publication day before it) and the amount
+converted to -to, as the mnb_rate, mnb_rate_day and amount_<to> columns or fields;
+the rates of all the records are fetched with one call:
+	mnbarf [options] enrich [-in=auto|csv|ndjson] [-date=date] [-amount=amount] [-currency=currency] [-to=HUF] [-round=2] [<input>|-]
+
+Calculate with amounts of currencies, converted through HUF with the MNB rates of the day after "@"
+(today by default, or the last publication day before it), into the currency after "in" (HUF by default);
+amounts can be added, subtracted, multiplied and divided by plain numbers, and parenthesized.
+It prints the result and the rates used (as JSON with -format=json):
+	mnbarf [options] calc [-round=2] '100 EUR + 2500 JPY - 30 USD in CHF @ 2024-05-02'
+
+Print the candidate exchange rates of an invoice in <currency>, by the Hungarian VAT Act:
+the MNB rate and the ECB reference rate converted through EUR, published on the day of supply
+(today by default) or on the last publication day before it. The exchange rates are the HUF value
+of one unit, with 6 decimals, as the NAV Online Invoice exchangeRate field expects;
+each comes with its source, effective day, published quotes and the applied rule:
+	mnbarf [options] invoice-rate [-day=2024-05-04] USD
+
+Generate a static, browsable archive of the rates into <outdir>: a page for each currency,
+year and month, with the rates, charts and statistics, a base rate history page
+(starting with the rate in effect on -from), and a JSON sidecar file for each page;
+from MNB or the rates stored by the daemon in -store:
+	mnbarf [options] site [-from=2006-01-02] [-to=2006-01-02] [-currencies=EUR,USD] [-store=<dir>] [-baserate=true] <outdir>
+
+Evaluate the alert rules of -rules on the current (or -day's) rates, compared to the previous publication day,
+print the fired ones to stderr, fire the hooks (see daemon) with them (event "alert"), and exit with status 2:
+	mnbarf alert [-rules=alerts.json] [-day=2006-01-02] [-exec=<command>] [-webhook=<url>]
+The rules file is a JSON array of rules with either "Currency" (per unit rate, in HUF),
+or "BaseRate": true (in percent), and any of "Above", "Below", "ChangePercent"
+(maximum move, in either direction) and "Changed" conditions; for example
+	[{"Currency": "EUR", "ChangePercent": 1}, {"Name": "USD hedge", "Currency": "USD", "Above": 400},
+	 {"BaseRate": true, "Changed": true}]
+
+Run as a daemon, which polls the current rates on business days from -publish-at
+(Budapest time), till the new day's rates appear, then stores them in the -state directory
+and writes them to -write-dir (in -format), loads them into -dsn and fires the hooks:
+	mnbarf [options] daemon [-state=<dir>] [-publish-at=11:45] [-poll-for=4h] [-poll-interval=5m] \
+		[-write-dir=<dir>] [-dsn=<dsn>] [-alerts=<file>] [-exec=<command>] [-webhook=<url> -webhook-secret=<secret>]
+The hooks fire on new rates (event "rates") and on a new base rate (event "baserate"),
+with {"Event", "Detected", "Rates" or "BaseRate"} as JSON: -exec gets it on stdin
+(and the event in $MNB_EVENT), -webhook as a POST body, with the X-MNB-Event header,
+and X-MNB-Signature: sha256=<hex HMAC-SHA256 of the body with -webhook-secret>.
+Each hook attempt is canceled after -hook-timeout; failed hooks are retried -hook-retries times,
+then appended to -dead-letter.
+With -alerts, the alert rules are evaluated on the new rates (see alert).
 
 -url http://www.mnb.hu/arfolyamok.asmx
 
@@ -189,7 +941,7 @@ Generate (and build) new webservice client
 
 `,
 		Subcommands: append(append(append(append(make([]*ffcli.Command, 0, 16),
-			&currentCmd, &infoCmd),
+			&currentCmd, &infoCmd, &loadCmd, &serveCmd, &proxyCmd, &exporterCmd, &grpcCmd, &statsCmd, &analyzeCmd, &revalueCmd, &realizeCmd, &loanCmd, &enrichCmd, &calcCmd, &invoiceRateCmd, &siteCmd, &alertCmd, &daemonCmd),
 			alias(&baserateCmd, "alapkamat", "kamat", "rate")...),
 			alias(&currenciesCmd, "currency", "curr")...),
 			alias(&ratesCmd, "rates")...),
@@ -201,6 +953,12 @@ Generate (and build) new webservice client
 	if err := app.Parse(os.Args[1:]); err != nil {
 		return err
 	}
+	if *flagLocale != "" {
+		var err error
+		if outLocale, err = mnb.NewLocale(*flagLocale); err != nil {
+			return fmt.Errorf("locale %q: %w", *flagLocale, err)
+		}
+	}
 	mnbLogger := slog.Default()
 	if verbose > 0 {
 		mnbLogger = logger.WithGroup("mnb")
@@ -214,10 +972,13 @@ Generate (and build) new webservice client
 	return app.Run(ctx)
 }
 
+// defaultBusinessDays is the number of business days in the default period: about a month.
+const defaultBusinessDays = 22
+
 func parseDates(beginS, endS string) (begin, end time.Time, err error) {
 	if beginS == "" {
-		begin = time.Now().AddDate(0, 0, -30)
-		end = time.Now().AddDate(0, 0, -1)
+		end = mnb.DefaultCalendar.ExpectedPublicationDay(time.Now().AddDate(0, 0, -1))
+		begin = mnb.DefaultCalendar.AddBusinessDays(end, -(defaultBusinessDays - 1))
 		return
 	}
 	begin, err = time.Parse("2006-01-02", beginS)
@@ -236,38 +997,88 @@ func parseDates(beginS, endS string) (begin, end time.Time, err error) {
 	return
 }
 
-func printDayRates(days []mnb.DayRates, outFormat string) error {
-	for i := range days {
-		days[i].Rates = append(days[i].Rates, mnb.Rate{
-			Currency: "HUF", Unit: 1, Rate: mnb.NewDouble(1, 0),
-		})
+// outLocale is used to format the numbers and dates of the csv and template outputs.
+var outLocale mnb.Locale
+
+// csvSeparator returns the field separator of the csv output:
+// semicolon if the locale uses decimal comma.
+func csvSeparator() rune {
+	if outLocale.Decimal == "," {
+		return ';'
+	}
+	return ','
+}
+
+func printDayRates(w io.Writer, days []mnb.DayRates, outFormat string) error {
+	return printDayRatesFrom(w, days, nil, outFormat)
+}
+
+// printFilledDayRates prints the calendar-filled rates, with the publication day each comes from.
+func printFilledDayRates(w io.Writer, filled []mnb.FilledDayRates, outFormat string) error {
+	days, sources := make([]mnb.DayRates, len(filled)), make([]mnb.Date, len(filled))
+	for i, f := range filled {
+		days[i], sources[i] = f.DayRates, f.Source
+	}
+	return printDayRatesFrom(w, days, sources, outFormat)
+}
+
+// printDayRatesFrom prints the days, with the source publication day of each day, if sources is not nil.
+func printDayRatesFrom(w io.Writer, days []mnb.DayRates, sources []mnb.Date, outFormat string) error {
+	source := func(i int) string {
+		if sources == nil {
+			return ""
+		}
+		return sources[i].String()
+	}
+	// the sql format fills the same table as the load command, which doesn't store HUF
+	if outFormat != "sql" {
+		for i := range days {
+			days[i].Rates = append(days[i].Rates, mnb.Rate{
+				Currency: "HUF", Unit: 1, Rate: mnb.NewDouble(1, 0),
+			})
+		}
 	}
 	type rowStruct struct {
 		Day      string
 		Currency string
 		Unit     int
 		Rate     string
+		Source   string `json:",omitempty"`
 	}
 
-	bw := bufio.NewWriter(os.Stdout)
+	bw := bufio.NewWriter(w)
 	defer bw.Flush()
 
 	switch outFormat {
 	case "csv":
-		fmt.Println("date,currency,unit,rate(HUF)")
-		for _, day := range days {
-			dS := day.Day.String()
+		cw := csv.NewWriter(bw)
+		cw.Comma = csvSeparator()
+		header := []string{"date", "currency", "unit", "rate(HUF)"}
+		if sources != nil {
+			header = append(header, "source")
+		}
+		_ = cw.Write(header)
+		for i, day := range days {
+			dS := outLocale.FormatDate(day.Day)
 			for _, rate := range day.Rates {
-				fmt.Fprintf(bw, "%s,%s,%d,%s\n", dS, rate.Currency, rate.Unit, rate.Rate.String())
+				rec := []string{dS, rate.Currency, strconv.Itoa(rate.Unit), outLocale.FormatDouble(rate.Rate)}
+				if sources != nil {
+					rec = append(rec, outLocale.FormatDate(sources[i]))
+				}
+				_ = cw.Write(rec)
 			}
 		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return err
+		}
 
 	case "json":
 		enc := json.NewEncoder(bw)
 		var row rowStruct
 		_, _ = bw.WriteString("[")
-		for _, day := range days {
-			row.Day = day.Day.String()
+		for i, day := range days {
+			row.Day, row.Source = day.Day.String(), source(i)
 			for _, rate := range day.Rates {
 				row.Currency, row.Unit, row.Rate = rate.Currency, rate.Unit, rate.Rate.String()
 				if err := enc.Encode(row); err != nil {
@@ -278,36 +1089,59 @@ func printDayRates(days []mnb.DayRates, outFormat string) error {
 		}
 		_, _ = bw.WriteString("]")
 
+	case "sql":
+		if err := writeSQLDayRates(bw, days, sqlOpts); err != nil {
+			logger.Info("writeSQLDayRates", "error", err)
+			return err
+		}
+
+	case "html", "markdown", "md":
+		rep, err := newRatesReport(days)
+		if err != nil {
+			return err
+		}
+		if outFormat == "html" {
+			return rep.WriteHTML(bw)
+		}
+		return rep.WriteMarkdown(bw)
+
 	default: // template
-		tmpl, err := template.New("row").Parse(outFormat)
+		tmpl, err := parseOutTemplate(outFormat)
 		if err != nil {
 			logger.Info("template parse", "error", err)
-			os.Exit(4)
+			return err
 		}
-		var row rowStruct
-		_, _ = bw.WriteString("[")
-		for _, day := range days {
-			row.Day = day.Day.String()
+		type templateRow struct {
+			rowStruct
+			Date     mnb.Date
+			Decimal  mnb.Double
+			DayRates mnb.DayRates
+		}
+		var rows []templateRow
+		for i, day := range days {
 			for _, rate := range day.Rates {
-				row.Currency, row.Unit, row.Rate = rate.Currency, rate.Unit, rate.Rate.String()
-				if err := tmpl.Execute(bw, row); err != nil {
-					logger.Info("encoding", "row", row, "error", err)
-					return err
-				}
+				rows = append(rows, templateRow{
+					rowStruct: rowStruct{Day: day.Day.String(), Currency: rate.Currency, Unit: rate.Unit, Rate: rate.Rate.String(), Source: source(i)},
+					Date:      day.Day, Decimal: rate.Rate, DayRates: day,
+				})
 			}
 		}
+		if err := executeOutTemplate(bw, tmpl, days, rows); err != nil {
+			logger.Info("template execute", "error", err)
+			return err
+		}
 	}
 	return nil
 }
 
-func printBaseRates(rates []mnb.MNBBaseRate, outFormat string) error {
-	bw := bufio.NewWriter(os.Stdout)
+func printBaseRates(w io.Writer, rates []mnb.MNBBaseRate, outFormat string) error {
+	bw := bufio.NewWriter(w)
 	defer bw.Flush()
 
 	switch outFormat {
 	case "csv":
 		for _, rate := range rates {
-			fmt.Fprintf(bw, "%s;%s\n", rate.Publication, rate.Rate)
+			fmt.Fprintf(bw, "%s;%s\n", outLocale.FormatDate(rate.Publication), outLocale.FormatDouble(rate.Rate))
 		}
 
 	case "json":
@@ -322,18 +1156,42 @@ func printBaseRates(rates []mnb.MNBBaseRate, outFormat string) error {
 		}
 		_, _ = bw.WriteString("]")
 
+	case "sql":
+		if err := writeSQLBaseRates(bw, rates, sqlOpts); err != nil {
+			logger.Info("writeSQLBaseRates", "error", err)
+			return err
+		}
+
+	case "html", "markdown", "md":
+		rep, err := newBaseRatesReport(rates)
+		if err != nil {
+			return err
+		}
+		if outFormat == "html" {
+			return rep.WriteHTML(bw)
+		}
+		return rep.WriteMarkdown(bw)
+
 	default: // template
-		tmpl, err := template.New("row").Parse(outFormat)
+		tmpl, err := parseOutTemplate(outFormat)
 		if err != nil {
 			logger.Info("template parse", "error", err)
-			os.Exit(4)
+			return err
 		}
-		_, _ = bw.WriteString("[")
-		for _, rate := range rates {
-			if err := tmpl.Execute(bw, rate); err != nil {
-				logger.Info("encoding", "rate", rate, "error", err)
-				return err
-			}
+		// the same Day, Date and Decimal fields as the exchange rate rows
+		type templateRow struct {
+			mnb.MNBBaseRate
+			Day     string
+			Date    mnb.Date
+			Decimal mnb.Double
+		}
+		rows := make([]templateRow, len(rates))
+		for i, rate := range rates {
+			rows[i] = templateRow{MNBBaseRate: rate, Day: rate.Publication.String(), Date: rate.Publication, Decimal: rate.Rate}
+		}
+		if err := executeOutTemplate(bw, tmpl, rates, rows); err != nil {
+			logger.Info("template execute", "error", err)
+			return err
 		}
 	}
 	return nil
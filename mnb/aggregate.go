@@ -0,0 +1,125 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mnb
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Period is the length of the resampling periods.
+type Period string
+
+const (
+	Week    = Period("week")
+	Month   = Period("month")
+	Quarter = Period("quarter")
+	Year    = Period("year")
+)
+
+// ParsePeriod parses the period name (week, month, quarter or year).
+func ParsePeriod(s string) (Period, error) {
+	p := Period(strings.ToLower(strings.TrimSpace(s)))
+	switch p {
+	case Week, Month, Quarter, Year:
+		return p, nil
+	}
+	return p, fmt.Errorf("unknown period %q (week, month, quarter or year is accepted)", s)
+}
+
+// Start returns the first day of the period containing t (weeks start on Monday).
+func (p Period) Start(t time.Time) time.Time {
+	y, m, d := t.Date()
+	switch p {
+	case Week:
+		return time.Date(y, m, d-(int(t.Weekday())+6)%7, 0, 0, 0, 0, time.UTC)
+	case Month:
+		return time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+	case Quarter:
+		return time.Date(y, m-(m-1)%3, 1, 0, 0, 0, 0, time.UTC)
+	case Year:
+		return time.Date(y, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// End returns the last day of the period containing t.
+func (p Period) End(t time.Time) time.Time {
+	start := p.Start(t)
+	switch p {
+	case Week:
+		return start.AddDate(0, 0, 6)
+	case Month:
+		return start.AddDate(0, 1, -1)
+	case Quarter:
+		return start.AddDate(0, 3, -1)
+	case Year:
+		return start.AddDate(1, 0, -1)
+	}
+	return start
+}
+
+// Label returns the name of the period containing t: 2024-W18, 2024-05, 2024-Q2 or 2024.
+func (p Period) Label(t time.Time) string {
+	switch p {
+	case Week:
+		y, w := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", y, w)
+	case Month:
+		return t.Format("2006-01")
+	case Quarter:
+		return fmt.Sprintf("%d-Q%d", t.Year(), (int(t.Month())+2)/3)
+	case Year:
+		return t.Format("2006")
+	}
+	return t.Format("2006-01-02")
+}
+
+// Aggregate is the Summary of a Series in a period.
+type Aggregate struct {
+	Period     string
+	Start, End Date
+	Summary
+}
+
+// Resample returns the Summary of each period of the series, in ascending order.
+func (s Series) Resample(p Period) ([]Aggregate, error) {
+	var aggs []Aggregate
+	for i := 0; i < len(s.Observations); {
+		t := time.Time(s.Observations[i].Day)
+		end := p.End(t)
+		j := i + 1
+		for j < len(s.Observations) && !time.Time(s.Observations[j].Day).After(end) {
+			j++
+		}
+		sum, err := Series{Currency: s.Currency, Unit: s.Unit, Observations: s.Observations[i:j]}.Summarize()
+		if err != nil {
+			return aggs, fmt.Errorf("%s %s: %w", s.Currency, p.Label(t), err)
+		}
+		aggs = append(aggs, Aggregate{Period: p.Label(t), Start: Date(p.Start(t)), End: Date(end), Summary: sum})
+		i = j
+	}
+	return aggs, nil
+}
+
+// Resample returns the per-period Summary of each currency of the days,
+// ordered by currency, then period.
+func Resample(days []DayRates, p Period) ([]Aggregate, error) {
+	var aggs []Aggregate
+	for _, s := range SplitSeries(days) {
+		a, err := s.Resample(p)
+		if err != nil {
+			return aggs, err
+		}
+		aggs = append(aggs, a...)
+	}
+	slices.SortStableFunc(aggs, func(a, b Aggregate) int {
+		return cmp.Or(cmp.Compare(a.Currency, b.Currency), time.Time(a.Start).Compare(time.Time(b.Start)))
+	})
+	return aggs, nil
+}
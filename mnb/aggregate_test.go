@@ -0,0 +1,93 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mnb
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestPeriod(t *testing.T) {
+	for _, tc := range []struct {
+		Period            Period
+		Day               string
+		Start, End, Label string
+	}{
+		{Week, "2024-05-01", "2024-04-29", "2024-05-05", "2024-W18"},
+		{Week, "2024-05-05", "2024-04-29", "2024-05-05", "2024-W18"}, // Sunday
+		{Week, "2024-12-31", "2024-12-30", "2025-01-05", "2025-W01"},
+		{Month, "2024-02-10", "2024-02-01", "2024-02-29", "2024-02"},
+		{Quarter, "2024-05-01", "2024-04-01", "2024-06-30", "2024-Q2"},
+		{Quarter, "2024-12-31", "2024-10-01", "2024-12-31", "2024-Q4"},
+		{Year, "2024-05-01", "2024-01-01", "2024-12-31", "2024"},
+		{"", "2024-05-01", "2024-05-01", "2024-05-01", "2024-05-01"},
+	} {
+		day := parseTestDay(t, tc.Day)
+		got := fmt.Sprintf("%s %s %s",
+			tc.Period.Start(day).Format("2006-01-02"), tc.Period.End(day).Format("2006-01-02"), tc.Period.Label(day))
+		if want := tc.Start + " " + tc.End + " " + tc.Label; got != want {
+			t.Errorf("%s of %s: got %s, wanted %s", tc.Period, tc.Day, got, want)
+		}
+	}
+	for _, s := range []string{"week", " Month", "QUARTER", "year"} {
+		if _, err := ParsePeriod(s); err != nil {
+			t.Errorf("%q: %+v", s, err)
+		}
+	}
+	if _, err := ParsePeriod("day"); err == nil {
+		t.Error("day: no error")
+	}
+}
+
+func TestResample(t *testing.T) {
+	days := testDays(t,
+		"2024-04-29 EUR=390 USD=360",
+		"2024-04-30 EUR=392",
+		"2024-05-02 EUR=391 USD=362",
+		"2024-05-31 EUR=389 USD=361",
+		"2024-06-03 EUR=393 USD=365",
+	)
+	for _, tc := range []struct {
+		Period Period
+		// Want is the "currency period start..end count first-last min/max" list.
+		Want []string
+	}{
+		{Month, []string{
+			"EUR 2024-04 2024-04-01..2024-04-30 2 390-392 390/392",
+			"EUR 2024-05 2024-05-01..2024-05-31 2 391-389 389/391",
+			"EUR 2024-06 2024-06-01..2024-06-30 1 393-393 393/393",
+			"USD 2024-04 2024-04-01..2024-04-30 1 360-360 360/360",
+			"USD 2024-05 2024-05-01..2024-05-31 2 362-361 361/362",
+			"USD 2024-06 2024-06-01..2024-06-30 1 365-365 365/365",
+		}},
+		{Week, []string{
+			"EUR 2024-W18 2024-04-29..2024-05-05 3 390-391 390/392",
+			"EUR 2024-W22 2024-05-27..2024-06-02 1 389-389 389/389",
+			"EUR 2024-W23 2024-06-03..2024-06-09 1 393-393 393/393",
+			"USD 2024-W18 2024-04-29..2024-05-05 2 360-362 360/362",
+			"USD 2024-W22 2024-05-27..2024-06-02 1 361-361 361/361",
+			"USD 2024-W23 2024-06-03..2024-06-09 1 365-365 365/365",
+		}},
+		{Year, []string{
+			"EUR 2024 2024-01-01..2024-12-31 5 390-393 389/393",
+			"USD 2024 2024-01-01..2024-12-31 4 360-365 360/365",
+		}},
+	} {
+		aggs, err := Resample(days, tc.Period)
+		if err != nil {
+			t.Fatalf("%s: %+v", tc.Period, err)
+		}
+		var got []string
+		for _, a := range aggs {
+			got = append(got, fmt.Sprintf("%s %s %s..%s %d %s-%s %s/%s",
+				a.Currency, a.Period, a.Start, a.End, a.Count,
+				a.First.Rate.String(), a.Last.Rate.String(), a.Min.Rate.String(), a.Max.Rate.String()))
+		}
+		if g, w := strings.Join(got, "\n"), strings.Join(tc.Want, "\n"); g != w {
+			t.Errorf("%s: got\n%s\nwanted\n%s", tc.Period, g, w)
+		}
+	}
+}
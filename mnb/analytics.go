@@ -0,0 +1,249 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mnb
+
+import (
+	"encoding/json"
+	"math"
+	"time"
+)
+
+// The analytics are statistical estimates, so they're computed in float64, not in Double.
+
+// Float is a float64 which is marshaled to JSON as null when it's NaN or infinite
+// (such as the return from a zero or missing rate), as JSON has no such numbers.
+type Float float64
+
+// MarshalJSON implements json.Marshaler.
+func (f Float) MarshalJSON() ([]byte, error) {
+	if math.IsNaN(float64(f)) || math.IsInf(float64(f), 0) {
+		return []byte("null"), nil
+	}
+	return json.Marshal(float64(f))
+}
+
+// BusinessDaysPerYear is the number of publication days in a year, used for annualizing the daily volatility.
+const BusinessDaysPerYear = 250
+
+// PeriodsPerYear returns the number of periods in a year: for annualizing the volatility of periodic returns.
+// The empty Period means the publication days.
+func (p Period) PeriodsPerYear() float64 {
+	switch p {
+	case Week:
+		return 52
+	case Month:
+		return 12
+	case Quarter:
+		return 4
+	case Year:
+		return 1
+	}
+	return BusinessDaysPerYear
+}
+
+// Return is the change of the rate from the observation on From to the one on Day.
+type Return struct {
+	From, Day Date
+	// Rate is the rate on Day.
+	Rate Double
+	// Return is the relative change (Rate/previous - 1), LogReturn is ln(Rate/previous).
+	Return, LogReturn Float
+}
+
+// Returns returns the returns between the consecutive observations.
+func (s Series) Returns() []Return {
+	return returns(s.Observations)
+}
+
+// PeriodReturns returns the returns between the last observations of the consecutive periods.
+// The first period is measured from its first observation.
+func (s Series) PeriodReturns(p Period) []Return {
+	if p == "" {
+		return s.Returns()
+	}
+	var obs []Observation
+	for i, o := range s.Observations {
+		if i == 0 {
+			obs = append(obs, o)
+			continue
+		}
+		if i+1 == len(s.Observations) || !p.Start(time.Time(s.Observations[i+1].Day)).Equal(p.Start(time.Time(o.Day))) {
+			obs = append(obs, o)
+		}
+	}
+	return returns(obs)
+}
+
+func returns(obs []Observation) []Return {
+	if len(obs) < 2 {
+		return nil
+	}
+	rets := make([]Return, 0, len(obs)-1)
+	prev := obs[0].Rate.Float64()
+	for i, o := range obs[1:] {
+		cur := o.Rate.Float64()
+		rets = append(rets, Return{
+			From: obs[i].Day, Day: o.Day, Rate: o.Rate,
+			Return: Float(cur/prev - 1), LogReturn: Float(math.Log(cur / prev)),
+		})
+		prev = cur
+	}
+	return rets
+}
+
+// Volatility is the standard deviation of the log returns of a window ending on Day.
+type Volatility struct {
+	Day                Date
+	StdDev, Annualized Float
+}
+
+// RollingVolatility returns the volatility of each window of the returns,
+// annualized with periodsPerYear (see Period.PeriodsPerYear).
+func RollingVolatility(rets []Return, window int, periodsPerYear float64) []Volatility {
+	if window < 2 || len(rets) < window {
+		return nil
+	}
+	vols := make([]Volatility, 0, len(rets)-window+1)
+	logs := logReturns(rets)
+	for i := window; i <= len(rets); i++ {
+		sd := stdDev(logs[i-window : i])
+		vols = append(vols, Volatility{Day: rets[i-1].Day, StdDev: Float(sd), Annualized: Float(sd * math.Sqrt(periodsPerYear))})
+	}
+	return vols
+}
+
+// Analysis is the return and risk statistics of a Series.
+type Analysis struct {
+	Currency string
+	Unit     int
+	// Period of the returns, empty for the daily returns.
+	Period Period `json:",omitempty"`
+	Count  int
+	// TotalReturn is the relative change over the whole series.
+	TotalReturn Float
+	// MeanLogReturn and StdDev are the mean and the sample standard deviation of the log returns.
+	MeanLogReturn, StdDev Float
+	// Volatility is the annualized StdDev.
+	Volatility Float
+	Returns    []Return     `json:",omitempty"`
+	Rolling    []Volatility `json:",omitempty"`
+}
+
+// Analyze returns the Analysis of the series, with the returns of the period
+// (the publication days for the empty Period) and the rolling volatility of window returns.
+func (s Series) Analyze(p Period, window int) Analysis {
+	rets := s.PeriodReturns(p)
+	a := Analysis{Currency: s.Currency, Unit: s.Unit, Period: p, Count: len(rets), Returns: rets}
+	if len(rets) == 0 {
+		return a
+	}
+	logs := logReturns(rets)
+	sd := stdDev(logs)
+	a.TotalReturn = Float(math.Expm1(sum(logs)))
+	a.MeanLogReturn = Float(sum(logs) / float64(len(logs)))
+	a.StdDev = Float(sd)
+	a.Volatility = Float(sd * math.Sqrt(p.PeriodsPerYear()))
+	a.Rolling = RollingVolatility(rets, window, p.PeriodsPerYear())
+	return a
+}
+
+// Correlation is the correlation matrix of the log returns of the currencies.
+type Correlation struct {
+	Currencies []string
+	// Matrix[i][j] is the Pearson correlation of Currencies[i] and Currencies[j],
+	// over the days both have returns on; nil if there are less than 3 such days,
+	// or either is constant.
+	Matrix [][]*float64
+}
+
+// Correlate returns the Correlation of the returns of the series.
+func Correlate(series []Series, p Period) Correlation {
+	c := Correlation{Currencies: make([]string, len(series)), Matrix: make([][]*float64, len(series))}
+	rets := make([][]Return, len(series))
+	byDay := make([]map[string]float64, len(series))
+	for i, s := range series {
+		c.Currencies[i] = s.Currency
+		rets[i] = s.PeriodReturns(p)
+		byDay[i] = make(map[string]float64, len(rets[i]))
+		for _, r := range rets[i] {
+			byDay[i][r.Day.String()] = float64(r.LogReturn)
+		}
+	}
+	for i := range series {
+		c.Matrix[i] = make([]*float64, len(series))
+		for j := range series {
+			if j < i {
+				c.Matrix[i][j] = c.Matrix[j][i]
+				continue
+			}
+			var xs, ys []float64
+			for _, r := range rets[i] {
+				if y, ok := byDay[j][r.Day.String()]; ok {
+					xs, ys = append(xs, float64(r.LogReturn)), append(ys, y)
+				}
+			}
+			c.Matrix[i][j] = pearson(xs, ys)
+		}
+	}
+	return c
+}
+
+// Analyze returns the Analysis of each currency of the days, ordered by currency, and their Correlation.
+func Analyze(days []DayRates, p Period, window int) ([]Analysis, Correlation) {
+	series := SplitSeries(days)
+	analyses := make([]Analysis, len(series))
+	for i, s := range series {
+		analyses[i] = s.Analyze(p, window)
+	}
+	return analyses, Correlate(series, p)
+}
+
+func logReturns(rets []Return) []float64 {
+	logs := make([]float64, len(rets))
+	for i, r := range rets {
+		logs[i] = float64(r.LogReturn)
+	}
+	return logs
+}
+
+func sum(xs []float64) float64 {
+	var s float64
+	for _, x := range xs {
+		s += x
+	}
+	return s
+}
+
+// stdDev returns the sample standard deviation.
+func stdDev(xs []float64) float64 {
+	if len(xs) < 2 {
+		return 0
+	}
+	mean := sum(xs) / float64(len(xs))
+	var ss float64
+	for _, x := range xs {
+		ss += (x - mean) * (x - mean)
+	}
+	return math.Sqrt(ss / float64(len(xs)-1))
+}
+
+func pearson(xs, ys []float64) *float64 {
+	if len(xs) < 3 {
+		return nil
+	}
+	mx, my := sum(xs)/float64(len(xs)), sum(ys)/float64(len(ys))
+	var sxy, sxx, syy float64
+	for i := range xs {
+		dx, dy := xs[i]-mx, ys[i]-my
+		sxy += dx * dy
+		sxx += dx * dx
+		syy += dy * dy
+	}
+	if sxx == 0 || syy == 0 {
+		return nil
+	}
+	r := sxy / math.Sqrt(sxx*syy)
+	return &r
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mnb
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestFloatMarshalJSON(t *testing.T) {
+	for _, tc := range []struct {
+		Float Float
+		Want  string
+	}{
+		{0, "0"},
+		{-0.25, "-0.25"},
+		{1e-9, "1e-9"},
+		{Float(math.NaN()), "null"},
+		{Float(math.Inf(1)), "null"},
+		{Float(math.Inf(-1)), "null"},
+	} {
+		b, err := json.Marshal(tc.Float)
+		if err != nil {
+			t.Errorf("%v: %+v", tc.Float, err)
+		} else if got := string(b); got != tc.Want {
+			t.Errorf("%v: got %s, wanted %s", tc.Float, got, tc.Want)
+		}
+	}
+}
+
+func TestAnalyzeJSONZeroRate(t *testing.T) {
+	s := testSeries(t, "2024-05-02", "392", "2024-05-03", "0", "2024-05-06", "394", "2024-05-07", "395")
+	a := s.Analyze("", 2)
+	if a.Count != 3 {
+		t.Errorf("got count %d, wanted 3", a.Count)
+	}
+	b, err := json.Marshal(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"LogReturn":null`) {
+		t.Errorf("no null log return in %s", b)
+	}
+	var back struct {
+		Returns []struct{ Return, LogReturn *float64 }
+	}
+	if err := json.Unmarshal(b, &back); err != nil {
+		t.Fatalf("%s: %+v", b, err)
+	}
+	if r := back.Returns[0].Return; r == nil || *r != -1 {
+		t.Errorf("got return %v, wanted -1", r)
+	}
+	if r := back.Returns[1].Return; r != nil {
+		t.Errorf("got return %v from the zero rate, wanted null", *r)
+	}
+}
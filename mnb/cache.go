@@ -0,0 +1,109 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mnb
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Cache stores the responses of the webservice calls, keyed by the URL and the request.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte)
+}
+
+// MemoryCache is an in-memory Cache, its entries expire after TTL.
+type MemoryCache struct {
+	TTL time.Duration
+	mu  sync.Mutex
+	m   map[string]memoryCacheEntry
+}
+
+type memoryCacheEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+// NewMemoryCache returns a MemoryCache with the given TTL.
+func NewMemoryCache(ttl time.Duration) *MemoryCache {
+	return &MemoryCache{TTL: ttl, m: make(map[string]memoryCacheEntry)}
+}
+
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.m[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(e.expires) {
+		delete(c.m, key)
+		return nil, false
+	}
+	return e.value, true
+}
+
+func (c *MemoryCache) Set(key string, value []byte) {
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.m == nil {
+		c.m = make(map[string]memoryCacheEntry)
+	} else if len(c.m) >= 1024 {
+		for k, e := range c.m {
+			if now.After(e.expires) {
+				delete(c.m, k)
+			}
+		}
+	}
+	c.m[key] = memoryCacheEntry{value: value, expires: now.Add(c.TTL)}
+}
+
+// DirCache is a Cache which stores the entries as files in Dir.
+// The entries expire after TTL, or never if TTL is zero.
+type DirCache struct {
+	Dir string
+	TTL time.Duration
+}
+
+func (c DirCache) path(key string) string {
+	hsh := sha256.Sum256([]byte(key))
+	return filepath.Join(c.Dir, hex.EncodeToString(hsh[:]))
+}
+
+func (c DirCache) Get(key string) ([]byte, bool) {
+	fn := c.path(key)
+	if c.TTL > 0 {
+		fi, err := os.Stat(fn)
+		if err != nil || time.Since(fi.ModTime()) > c.TTL {
+			return nil, false
+		}
+	}
+	b, err := os.ReadFile(fn)
+	return b, err == nil
+}
+
+func (c DirCache) Set(key string, value []byte) {
+	fn := c.path(key)
+	fh, err := os.CreateTemp(c.Dir, ".tmp-*")
+	if err != nil {
+		return
+	}
+	_, err = fh.Write(value)
+	if closeErr := fh.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(fh.Name(), fn)
+	}
+	if err != nil {
+		_ = os.Remove(fh.Name())
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mnb
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCacheKeyedByURL(t *testing.T) {
+	ctx := context.Background()
+	var callsA, callsB atomic.Int32
+	srvA := serveFakeMNB(t, testDays(t, "2024-05-03 EUR=390.50"), &callsA)
+	srvB := serveFakeMNB(t, testDays(t, "2024-05-03 EUR=391.00"), &callsB)
+	for _, cache := range []Cache{NewMemoryCache(time.Minute), DirCache{Dir: t.TempDir()}} {
+		callsA.Store(0)
+		callsB.Store(0)
+		wsA, wsB := NewMNBArfolyamService(srvA.URL, nil, nil), NewMNBArfolyamService(srvB.URL, nil, nil)
+		wsA.Cache, wsB.Cache = cache, cache
+		day := parseTestDay(t, "2024-05-03")
+		for i := 0; i < 2; i++ {
+			for _, tc := range []struct {
+				WS   MNBArfolyamService
+				Want string
+			}{{WS: wsA, Want: "390.50"}, {WS: wsB, Want: "391.00"}} {
+				days, err := tc.WS.GetExchangeRates(ctx, day, day, "EUR")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(days) != 1 || len(days[0].Rates) != 1 {
+					t.Fatalf("%s: got %+v", tc.WS.URL, days)
+				}
+				checkDouble(t, tc.WS.URL, days[0].Rates[0].Rate, tc.Want)
+			}
+		}
+		if a, b := callsA.Load(), callsB.Load(); a != 1 || b != 1 {
+			t.Errorf("%T: got %d and %d calls, wanted 1 each (then from the cache)", cache, a, b)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mnb
+
+import (
+	"sync"
+	"time"
+)
+
+// Calendar is the Hungarian business day calendar: MNB publishes rates on business days.
+//
+// The weekdays are business days, except the public holidays and the bridge rest days,
+// and the Saturdays the working days are moved to are business days.
+// The public holidays are computed from the rules of the Labour Code,
+// the moved days come from a built-in table, which can be extended with Set,
+// and cross-checked with the actual publications by Learn.
+type Calendar struct {
+	mu sync.RWMutex
+	// moved overrides the rules: true for the moved working days, false for the rest days.
+	moved map[time.Time]bool
+}
+
+// DefaultCalendar is the Calendar used by the fallback lookups of the service.
+// The lookups only read it; it changes only by explicit Set or Learn calls
+// (such as the daemon's, on each new publication), which are safe for concurrent use.
+var DefaultCalendar = NewCalendar()
+
+// NewCalendar returns a Calendar seeded with the built-in moved working days and rest days.
+func NewCalendar() *Calendar {
+	c := Calendar{moved: make(map[time.Time]bool, 2*len(movedDays))}
+	for _, m := range movedDays {
+		c.moved[m.Rest] = false
+		c.moved[m.Work] = true
+	}
+	return &c
+}
+
+// movedDays are the bridge rest days and the Saturdays they're worked off on,
+// as set by the yearly decrees of the ministry on the working day schedule around the public holidays
+// ("NGM rendelet a ... évi munkaszüneti napok körüli munkarendről").
+var movedDays = []struct{ Rest, Work time.Time }{
+	{ymd(2018, 3, 16), ymd(2018, 3, 10)},
+	{ymd(2018, 4, 30), ymd(2018, 4, 21)},
+	{ymd(2018, 10, 22), ymd(2018, 10, 13)},
+	{ymd(2018, 11, 2), ymd(2018, 11, 10)},
+	{ymd(2018, 12, 24), ymd(2018, 12, 1)},
+	{ymd(2018, 12, 31), ymd(2018, 12, 15)},
+	{ymd(2019, 8, 19), ymd(2019, 8, 10)},
+	{ymd(2019, 12, 24), ymd(2019, 12, 7)},
+	{ymd(2019, 12, 27), ymd(2019, 12, 14)},
+	{ymd(2020, 8, 21), ymd(2020, 8, 29)},
+	{ymd(2020, 12, 24), ymd(2020, 12, 12)},
+	{ymd(2021, 12, 24), ymd(2021, 12, 11)},
+	{ymd(2022, 3, 14), ymd(2022, 3, 26)},
+	{ymd(2022, 10, 31), ymd(2022, 10, 15)},
+	{ymd(2024, 8, 19), ymd(2024, 8, 3)},
+	{ymd(2024, 12, 24), ymd(2024, 12, 7)},
+	{ymd(2024, 12, 27), ymd(2024, 12, 14)},
+	{ymd(2025, 5, 2), ymd(2025, 5, 17)},
+	{ymd(2025, 10, 24), ymd(2025, 10, 18)},
+	{ymd(2026, 1, 2), ymd(2026, 1, 10)},
+	{ymd(2026, 8, 21), ymd(2026, 8, 8)},
+}
+
+func ymd(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+
+// dayOf returns the calendar day of t, as UTC midnight.
+func dayOf(t time.Time) time.Time { return ymd(t.Date()) }
+
+// Easter returns Easter Sunday of the year (Gregorian calendar).
+func Easter(year int) time.Time {
+	a, b, c := year%19, year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	return ymd(year, time.Month((h+l-7*m+114)/31), (h+l-7*m+114)%31+1)
+}
+
+// IsPublicHoliday reports whether the day is a Hungarian public holiday, by the rules alone:
+// the holidays of the Labour Code (2012. évi I. törvény 102. § (1)).
+// Good Friday is a holiday since 2017, and December 24 since 2025
+// (earlier it was a rest day only when the yearly decree moved it).
+func IsPublicHoliday(t time.Time) bool {
+	t = dayOf(t)
+	y, m, d := t.Date()
+	switch {
+	case m == time.January && d == 1,
+		m == time.March && d == 15,
+		m == time.May && d == 1,
+		m == time.August && d == 20,
+		m == time.October && d == 23,
+		m == time.November && d == 1,
+		m == time.December && (d == 25 || d == 26),
+		m == time.December && d == 24 && y >= 2025:
+		return true
+	}
+	easter := Easter(y)
+	switch t {
+	case easter.AddDate(0, 0, 1), easter.AddDate(0, 0, 50): // Easter Monday, Whit Monday
+		return true
+	case easter.AddDate(0, 0, -2): // Good Friday
+		return y >= 2017
+	}
+	return false
+}
+
+// Set records whether the day is a business day, overriding the rules.
+func (c *Calendar) Set(t time.Time, business bool) {
+	c.mu.Lock()
+	c.moved[dayOf(t)] = business
+	c.mu.Unlock()
+}
+
+// IsBusinessDay reports whether MNB is expected to publish rates on the day.
+func (c *Calendar) IsBusinessDay(t time.Time) bool {
+	t = dayOf(t)
+	c.mu.RLock()
+	business, ok := c.moved[t]
+	c.mu.RUnlock()
+	if ok {
+		return business
+	}
+	if wd := t.Weekday(); wd == time.Saturday || wd == time.Sunday {
+		return false
+	}
+	return !IsPublicHoliday(t)
+}
+
+// NextBusinessDay returns the first business day after the day of t.
+func (c *Calendar) NextBusinessDay(t time.Time) time.Time {
+	for t = dayOf(t).AddDate(0, 0, 1); !c.IsBusinessDay(t); t = t.AddDate(0, 0, 1) {
+	}
+	return t
+}
+
+// PrevBusinessDay returns the last business day before the day of t.
+func (c *Calendar) PrevBusinessDay(t time.Time) time.Time {
+	for t = dayOf(t).AddDate(0, 0, -1); !c.IsBusinessDay(t); t = t.AddDate(0, 0, -1) {
+	}
+	return t
+}
+
+// AddBusinessDays returns the n-th business day after (or before, for negative n) the day of t.
+func (c *Calendar) AddBusinessDays(t time.Time, n int) time.Time {
+	t = dayOf(t)
+	for ; n > 0; n-- {
+		t = c.NextBusinessDay(t)
+	}
+	for ; n < 0; n++ {
+		t = c.PrevBusinessDay(t)
+	}
+	return t
+}
+
+// ExpectedPublicationDay returns the day whose rates are in effect on the day of t:
+// the day itself if it's a business day, else the last business day before it.
+func (c *Calendar) ExpectedPublicationDay(t time.Time) time.Time {
+	if t = dayOf(t); c.IsBusinessDay(t) {
+		return t
+	}
+	return c.PrevBusinessDay(t)
+}
+
+// Learn cross-checks the calendar with the actual publications:
+// between the first and the last of the days, the days with rates are business days,
+// the days without are not.
+// The days must be all the publications of the period, not filtered by currency,
+// as a currency may be missing from some publications.
+// It returns the days the calendar had been wrong about, in ascending order.
+func (c *Calendar) Learn(days []DayRates) []Date {
+	if len(days) == 0 {
+		return nil
+	}
+	published := make(map[time.Time]bool, len(days))
+	first, last := dayOf(time.Time(days[0].Day)), dayOf(time.Time(days[0].Day))
+	for _, d := range days {
+		t := dayOf(time.Time(d.Day))
+		published[t] = true
+		if t.Before(first) {
+			first = t
+		}
+		if t.After(last) {
+			last = t
+		}
+	}
+	var wrong []Date
+	for t := first; !t.After(last); t = t.AddDate(0, 0, 1) {
+		if c.IsBusinessDay(t) != published[t] {
+			c.Set(t, published[t])
+			wrong = append(wrong, Date(t))
+		}
+	}
+	return wrong
+}
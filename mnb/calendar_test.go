@@ -0,0 +1,128 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mnb
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestEaster(t *testing.T) {
+	for year, want := range map[int]string{
+		2000: "2000-04-23",
+		2008: "2008-03-23",
+		2011: "2011-04-24",
+		2019: "2019-04-21",
+		2024: "2024-03-31",
+		2025: "2025-04-20",
+		2026: "2026-04-05",
+		2038: "2038-04-25",
+	} {
+		if got := Easter(year).Format("2006-01-02"); got != want {
+			t.Errorf("%d: got %s, wanted %s", year, got, want)
+		}
+	}
+}
+
+func TestIsPublicHoliday(t *testing.T) {
+	for _, tc := range []struct {
+		Day  string
+		Want bool
+	}{
+		{"2024-01-01", true},
+		{"2024-03-15", true},
+		{"2024-03-29", true},  // Good Friday
+		{"2016-03-25", false}, // Good Friday before 2017
+		{"2024-04-01", true},  // Easter Monday
+		{"2024-05-20", true},  // Whit Monday
+		{"2025-06-09", true},  // Whit Monday
+		{"2026-05-25", true},  // Whit Monday
+		{"2026-05-26", false},
+		{"2024-08-20", true},
+		{"2024-10-23", true},
+		{"2024-11-01", true},
+		{"2024-12-24", false}, // a rest day by the decree only
+		{"2025-12-24", true},
+		{"2026-12-24", true},
+		{"2024-12-25", true},
+		{"2024-12-26", true},
+		{"2024-12-27", false},
+	} {
+		if got := IsPublicHoliday(parseTestDay(t, tc.Day)); got != tc.Want {
+			t.Errorf("%s: got %t, wanted %t", tc.Day, got, tc.Want)
+		}
+	}
+}
+
+func TestCalendarIsBusinessDay(t *testing.T) {
+	c := NewCalendar()
+	for _, tc := range []struct {
+		Day  string
+		Want bool
+	}{
+		{"2024-05-03", true},  // Friday
+		{"2024-05-04", false}, // Saturday
+		{"2024-05-20", false}, // Whit Monday
+		{"2024-08-03", true},  // worked Saturday
+		{"2024-08-19", false}, // bridge day
+		{"2024-12-14", true},  // worked Saturday
+		{"2024-12-24", false}, // bridge day, before the holiday
+		{"2024-12-27", false}, // bridge day
+		{"2025-05-02", false},
+		{"2025-05-17", true},
+		{"2025-12-24", false}, // holiday
+		{"2026-01-02", false}, // bridge day
+		{"2026-01-10", true},  // worked Saturday
+		{"2026-08-08", true},  // worked Saturday
+		{"2026-08-21", false}, // bridge day
+		{"2026-10-19", true},
+	} {
+		if got := c.IsBusinessDay(parseTestDay(t, tc.Day)); got != tc.Want {
+			t.Errorf("%s: got %t, wanted %t", tc.Day, got, tc.Want)
+		}
+	}
+}
+
+func TestCalendarDays(t *testing.T) {
+	c := NewCalendar()
+	for _, tc := range []struct {
+		Name string
+		Got  time.Time
+		Want string
+	}{
+		{"expected on Sunday", c.ExpectedPublicationDay(parseTestDay(t, "2024-05-05")), "2024-05-03"},
+		{"expected on a business day", c.ExpectedPublicationDay(parseTestDay(t, "2024-05-03")), "2024-05-03"},
+		{"expected at Christmas 2024", c.ExpectedPublicationDay(parseTestDay(t, "2024-12-29")), "2024-12-23"},
+		{"expected on the 2026 bridge day", c.ExpectedPublicationDay(parseTestDay(t, "2026-01-02")), "2025-12-31"},
+		{"next after Easter", c.NextBusinessDay(parseTestDay(t, "2026-04-03")), "2026-04-07"},
+		{"prev before the worked Saturday", c.PrevBusinessDay(parseTestDay(t, "2026-01-12")), "2026-01-10"},
+		{"22 business days back", c.AddBusinessDays(parseTestDay(t, "2026-08-31"), -21), "2026-07-30"},
+		{"over the August holiday and bridge day", c.AddBusinessDays(parseTestDay(t, "2026-08-19"), 1), "2026-08-24"},
+	} {
+		if got := tc.Got.Format("2006-01-02"); got != tc.Want {
+			t.Errorf("%s: got %s, wanted %s", tc.Name, got, tc.Want)
+		}
+	}
+}
+
+func TestCalendarLearn(t *testing.T) {
+	c := NewCalendar()
+	var days []DayRates
+	// 2024-05-06..10, with an unexpected closure on Wednesday
+	for _, s := range []string{"2024-05-06", "2024-05-07", "2024-05-09", "2024-05-10"} {
+		days = append(days, DayRates{Day: Date(parseTestDay(t, s))})
+	}
+	wrong := c.Learn(days)
+	if want := []Date{Date(parseTestDay(t, "2024-05-08"))}; !slices.Equal(wrong, want) {
+		t.Errorf("got %v, wanted %v", wrong, want)
+	}
+	if c.IsBusinessDay(parseTestDay(t, "2024-05-08")) {
+		t.Error("2024-05-08 is still a business day")
+	}
+	if !NewCalendar().IsBusinessDay(parseTestDay(t, "2024-05-08")) {
+		t.Error("Learn changed the other calendars")
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mnb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/apd/v3"
+)
+
+// ErrNoRate is returned when there's no published rate for the currency or day.
+var ErrNoRate = errors.New("no rate")
+
+// Find returns the rate of the currency on the day. HUF is always found, with rate 1.
+func (d DayRates) Find(currency string) (Rate, bool) {
+	if currency == "HUF" {
+		return Rate{Currency: "HUF", Unit: 1, Rate: NewDouble(1, 0)}, true
+	}
+	for _, r := range d.Rates {
+		if r.Currency == currency {
+			return r, true
+		}
+	}
+	return Rate{}, false
+}
+
+// PerUnit returns the HUF value of one unit of the currency (the rate divided by the unit).
+func (r Rate) PerUnit() (Double, error) {
+	var z apd.Decimal
+	if r.Rate.Decimal == nil {
+		return Double{}, fmt.Errorf("%s: %w", r.Currency, ErrNoRate)
+	}
+	unit := r.Unit
+	if unit == 0 {
+		unit = 1
+	}
+	_, err := DecimalContext.Quo(&z, r.Rate.Decimal, apd.New(int64(unit), 0))
+	z.Reduce(&z)
+	return Double{Decimal: &z}, err
+}
+
+// CrossRate returns the price of one unit of from in to, through HUF.
+func (d DayRates) CrossRate(from, to string) (Double, error) {
+	rFrom, ok := d.Find(from)
+	if !ok {
+		return Double{}, fmt.Errorf("%s on %s: %w", from, d.Day, ErrNoRate)
+	}
+	rTo, ok := d.Find(to)
+	if !ok {
+		return Double{}, fmt.Errorf("%s on %s: %w", to, d.Day, ErrNoRate)
+	}
+	pFrom, err := rFrom.PerUnit()
+	if err != nil {
+		return Double{}, err
+	}
+	pTo, err := rTo.PerUnit()
+	if err != nil {
+		return Double{}, err
+	}
+	var z apd.Decimal
+	if _, err = DecimalContext.Quo(&z, pFrom.Decimal, pTo.Decimal); err != nil {
+		return Double{}, err
+	}
+	z.Reduce(&z)
+	return Double{Decimal: &z}, nil
+}
+
+// Convert the amount from one currency to the other, through HUF, with the rates of the day.
+func (d DayRates) Convert(amount Double, from, to string) (Double, error) {
+	if amount.Decimal == nil {
+		return Double{}, errors.New("no amount")
+	}
+	rate, err := d.CrossRate(from, to)
+	if err != nil {
+		return Double{}, err
+	}
+	var z apd.Decimal
+	if _, err = DecimalContext.Mul(&z, amount.Decimal, rate.Decimal); err != nil {
+		return Double{}, err
+	}
+	z.Reduce(&z)
+	return Double{Decimal: &z}, nil
+}
+
+// fallbackDays is the length of the period searched backwards for the last publication day,
+// longer than any holiday season without rates.
+const fallbackDays = 14
+
+// GetExchangeRatesAt returns the rates of the given day,
+// or the last publication day before it, if there were no rates published on that day.
+//
+// It asks from the expected publication day of DefaultCalendar first,
+// and searches fallbackDays backwards only if that has no rates (e.g. an unknown holiday,
+// or today's rates are not published yet).
+func (m MNBArfolyamService) GetExchangeRatesAt(ctx context.Context, day time.Time, currencies ...string) (DayRates, error) {
+	var found DayRates
+	for _, begin := range []time.Time{DefaultCalendar.ExpectedPublicationDay(day), day.AddDate(0, 0, -fallbackDays)} {
+		days, err := m.GetExchangeRates(ctx, begin, day, currencies...)
+		if err != nil {
+			return DayRates{}, err
+		}
+		for _, d := range days {
+			if t := time.Time(d.Day); !t.After(day) && (time.Time(found.Day).IsZero() || t.After(time.Time(found.Day))) {
+				found = d
+			}
+		}
+		if !time.Time(found.Day).IsZero() {
+			return found, nil
+		}
+	}
+	return found, fmt.Errorf("%v on %s: %w", currencies, day.Format("2006-01-02"), ErrNoRate)
+}
+
+// firstDate is the first day of the MNB data (the FirstDate of GetInfo).
+var firstDate = time.Date(1949, 1, 3, 0, 0, 0, 0, time.UTC)
+
+// GetBaseRateAt returns the base rate in effect on the given day: the last one published before or on it.
+func (m MNBAlapkamatService) GetBaseRateAt(ctx context.Context, day time.Time) (MNBBaseRate, error) {
+	var found MNBBaseRate
+	// The base rate may be unchanged for years: look back 5 years first, then the whole history.
+	for _, start := range []time.Time{day.AddDate(-5, 0, 0), firstDate} {
+		rates, err := m.GetCentralBankBaseRate(ctx, start, day)
+		if err != nil {
+			return found, err
+		}
+		for _, r := range rates {
+			if t := time.Time(r.Publication); !t.After(day) && (time.Time(found.Publication).IsZero() || t.After(time.Time(found.Publication))) {
+				found = r
+			}
+		}
+		if !time.Time(found.Publication).IsZero() {
+			return found, nil
+		}
+	}
+	return found, fmt.Errorf("base rate on %s: %w", day.Format("2006-01-02"), ErrNoRate)
+}
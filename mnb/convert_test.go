@@ -0,0 +1,73 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mnb
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestGetBaseRateAt(t *testing.T) {
+	rates := testBaseRates(t, "2012-08-29=7.00", "2016-05-24=0.90")
+	var mu sync.Mutex
+	var starts []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		var start, end string
+		for _, m := range rxSOAPParam.FindAllStringSubmatch(string(b), -1) {
+			switch m[1] {
+			case "startDate":
+				start = m[2]
+			case "endDate":
+				end = m[2]
+			}
+		}
+		mu.Lock()
+		starts = append(starts, start)
+		mu.Unlock()
+		var buf strings.Builder
+		for _, r := range rates {
+			if s := r.Publication.String(); s >= start && s <= end {
+				fmt.Fprintf(&buf, `<BaseRate publicationDate="%s">%s</BaseRate>`, s, strings.ReplaceAll(r.Rate.String(), ".", ","))
+			}
+		}
+		fmt.Fprintf(w, `<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/"><s:Body><GetCentralBankBaseRateResponse xmlns="http://www.mnb.hu/webservices/"><GetCentralBankBaseRateResult><![CDATA[<MNBCentralBankBaseRates>%s</MNBCentralBankBaseRates>]]></GetCentralBankBaseRateResult></GetCentralBankBaseRateResponse></s:Body></s:Envelope>`,
+			buf.String())
+	}))
+	defer srv.Close()
+	ws := NewMNBAlapkamatService(srv.URL, nil, nil)
+
+	for _, tc := range []struct {
+		Day, Want string
+		Starts    []string
+	}{
+		{Day: "2016-06-01", Want: "2016-05-24=0.90", Starts: []string{"2011-06-01"}},
+		{Day: "2016-05-23", Want: "2012-08-29=7.00", Starts: []string{"2011-05-23"}},
+		// unchanged for more than 5 years: the whole history is asked for
+		{Day: "2022-05-01", Want: "2016-05-24=0.90", Starts: []string{"2017-05-01", "1949-01-03"}},
+	} {
+		starts = starts[:0]
+		got, err := ws.GetBaseRateAt(context.Background(), parseTestDay(t, tc.Day))
+		if err != nil {
+			t.Fatalf("%s: %+v", tc.Day, err)
+		}
+		if s := got.Publication.String() + "=" + got.Rate.String(); s != tc.Want {
+			t.Errorf("%s: got %s, wanted %s", tc.Day, s, tc.Want)
+		}
+		if fmt.Sprint(starts) != fmt.Sprint(tc.Starts) {
+			t.Errorf("%s: asked from %q, wanted %q", tc.Day, starts, tc.Starts)
+		}
+	}
+
+	if _, err := ws.GetBaseRateAt(context.Background(), parseTestDay(t, "2000-01-01")); err == nil {
+		t.Error("wanted ErrNoRate before the first base rate")
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mnb
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+const (
+	// ECBHist90dURL is the ECB euro foreign exchange reference rates feed of the last 90 days.
+	ECBHist90dURL = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-hist-90d.xml"
+	// ECBHistURL is the feed of the whole history, since 1999.
+	ECBHistURL = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-hist.xml"
+)
+
+// ECB is a client of the ECB euro foreign exchange reference rates feed.
+type ECB struct {
+	// URL of the feed; empty means ECBHist90dURL for the last 80 days, ECBHistURL before.
+	URL string
+	*slog.Logger
+	*http.Client
+}
+
+// NewECB returns an ECB client of the feed at URL (see ECB.URL).
+func NewECB(URL string, client *http.Client, logger *slog.Logger) ECB {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return ECB{URL: URL, Logger: logger, Client: client}
+}
+
+// ECBDay is the ECB reference rates of a day: the price of one EUR in each currency.
+type ECBDay struct {
+	Day   Date
+	Rates map[string]Double
+}
+
+// HUFRates returns the rates of the day in HUF (like MNB's): one unit of each currency
+// (EUR included) is worth the EUR/HUF rate divided by the EUR/currency rate.
+func (d ECBDay) HUFRates() (DayRates, error) {
+	huf, ok := d.Rates["HUF"]
+	if !ok {
+		return DayRates{Day: d.Day}, fmt.Errorf("HUF on %s: %w", d.Day, ErrNoRate)
+	}
+	day := DayRates{Day: d.Day, Rates: make([]Rate, 0, len(d.Rates))}
+	day.Rates = append(day.Rates, Rate{Currency: "EUR", Unit: 1, Rate: huf})
+	for c, r := range d.Rates {
+		if c == "HUF" {
+			continue
+		}
+		rate, err := huf.Quo(r)
+		if err != nil {
+			return day, fmt.Errorf("%s on %s: %w", c, d.Day, err)
+		}
+		day.Rates = append(day.Rates, Rate{Currency: c, Unit: 1, Rate: rate})
+	}
+	return day, nil
+}
+
+// ecbEnvelope is the gesmes:Envelope of the ECB feeds.
+type ecbEnvelope struct {
+	Days []struct {
+		Time  string `xml:"time,attr"`
+		Rates []struct {
+			Currency string `xml:"currency,attr"`
+			Rate     string `xml:"rate,attr"`
+		} `xml:"Cube"`
+	} `xml:"Cube>Cube"`
+}
+
+// GetRates returns the reference rates of the feed, from the days since begin (all, if it's zero).
+func (e ECB) GetRates(ctx context.Context, begin time.Time) ([]ECBDay, error) {
+	URL := e.URL
+	if URL == "" {
+		URL = ECBHistURL
+		if !begin.IsZero() && time.Since(begin) < 80*24*time.Hour {
+			URL = ECBHist90dURL
+		}
+	}
+	client := e.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	var env ecbEnvelope
+	var firstErr error
+	for iter := retryStrategy.Start(); ; {
+		err := func() error {
+			req, err := http.NewRequestWithContext(ctx, "GET", URL, nil)
+			if err != nil {
+				return err
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				return err
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode >= 400 {
+				io.Copy(io.Discard, resp.Body)
+				return fmt.Errorf("%s %q: %s", req.Method, req.URL, resp.Status)
+			}
+			env = ecbEnvelope{}
+			return xml.NewDecoder(resp.Body).Decode(&env)
+		}()
+		if err == nil {
+			break
+		}
+		if e.Logger != nil {
+			e.Logger.Debug("ECB", "url", URL, "error", err)
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+		if !iter.Next(ctx.Done()) {
+			return nil, firstErr
+		}
+	}
+
+	days := make([]ECBDay, 0, len(env.Days))
+	for _, d := range env.Days {
+		var day ECBDay
+		if err := day.Day.UnmarshalText([]byte(d.Time)); err != nil {
+			return days, err
+		}
+		if !begin.IsZero() && time.Time(day.Day).Before(dayOf(begin)) {
+			continue
+		}
+		day.Rates = make(map[string]Double, len(d.Rates))
+		for _, r := range d.Rates {
+			var rate Double
+			if err := rate.UnmarshalText([]byte(r.Rate)); err != nil {
+				return days, fmt.Errorf("%s %s: %w", d.Time, r.Currency, err)
+			}
+			day.Rates[r.Currency] = rate
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+// GetHistory returns the HUF rates (see ECBDay.HUFRates) of the days since begin, as a History.
+func (e ECB) GetHistory(ctx context.Context, begin time.Time) (History, error) {
+	ecbDays, err := e.GetRates(ctx, begin)
+	if err != nil {
+		return nil, err
+	}
+	days := make([]DayRates, 0, len(ecbDays))
+	for _, d := range ecbDays {
+		day, err := d.HUFRates()
+		if err != nil {
+			return nil, err
+		}
+		days = append(days, day)
+	}
+	return NewHistory(days), nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mnb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestECBDayHUFRates(t *testing.T) {
+	d := ECBDay{Day: Date(parseTestDay(t, "2024-05-03")), Rates: map[string]Double{
+		"HUF": testDouble(t, "400"), "USD": testDouble(t, "1.25"), "JPY": testDouble(t, "160"),
+	}}
+	day, err := d.HUFRates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := day.Day.String(); got != "2024-05-03" {
+		t.Errorf("got day %s", got)
+	}
+	if len(day.Rates) != 3 {
+		t.Errorf("got %d rates, wanted EUR, JPY and USD: %v", len(day.Rates), day.Rates)
+	}
+	for c, want := range map[string]string{"EUR": "400", "USD": "320", "JPY": "2.5"} {
+		r, ok := day.Find(c)
+		if !ok {
+			t.Errorf("%s is missing", c)
+			continue
+		}
+		if r.Unit != 1 {
+			t.Errorf("%s: got unit %d", c, r.Unit)
+		}
+		checkDouble(t, c, r.Rate, want)
+	}
+	if _, ok := day.Find("HUF"); !ok {
+		t.Error("HUF is not found")
+	}
+
+	delete(d.Rates, "HUF")
+	if _, err := d.HUFRates(); !errors.Is(err, ErrNoRate) {
+		t.Errorf("without HUF: got %+v, wanted %v", err, ErrNoRate)
+	}
+}
+
+func TestECBGetRates(t *testing.T) {
+	srv := serveFakeECB(t, nil,
+		"2024-05-03 USD=1.0730 HUF=390.30",
+		"2024-05-02 USD=1.0702 HUF=391.15",
+		"2024-04-30 USD=1.0670 HUF=392.00",
+	)
+	ecb := NewECB(srv.URL, nil, nil)
+	for _, tc := range []struct {
+		Begin string
+		Want  []string
+	}{
+		{"", []string{"2024-05-03", "2024-05-02", "2024-04-30"}},
+		{"2024-05-01", []string{"2024-05-03", "2024-05-02"}},
+		{"2024-05-04", nil},
+	} {
+		var begin Date
+		if tc.Begin != "" {
+			begin = Date(parseTestDay(t, tc.Begin))
+		}
+		days, err := ecb.GetRates(context.Background(), time.Time(begin))
+		if err != nil {
+			t.Fatalf("%s: %+v", tc.Begin, err)
+		}
+		var got []string
+		for _, d := range days {
+			got = append(got, d.Day.String())
+		}
+		if !slices.Equal(got, tc.Want) {
+			t.Errorf("%s: got %v, wanted %v", tc.Begin, got, tc.Want)
+		}
+		if len(days) != 0 {
+			checkDouble(t, "USD", days[0].Rates["USD"], "1.0730")
+			checkDouble(t, "HUF", days[0].Rates["HUF"], "390.30")
+		}
+	}
+}
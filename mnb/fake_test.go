@@ -0,0 +1,101 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mnb
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+var rxSOAPParam = regexp.MustCompile(`<web:(startDate|endDate|currencyNames)>([^<]*)</web:`)
+
+// serveFakeMNB starts a fake of the MNB GetExchangeRates SOAP call, answering from the days
+// with the same filtering of the days and currencies as the real one.
+// The server is closed at the end of the test; calls counts the requests.
+func serveFakeMNB(t *testing.T, days []DayRates, calls *atomic.Int32) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls != nil {
+			calls.Add(1)
+		}
+		action := r.Header.Get("SOAPAction")
+		name := action[strings.LastIndexByte(action, '/')+1:]
+		if name != "GetExchangeRates" {
+			http.Error(w, name+" is not faked", http.StatusNotImplemented)
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		var start, end string
+		var currencies []string
+		for _, m := range rxSOAPParam.FindAllStringSubmatch(string(b), -1) {
+			switch m[1] {
+			case "startDate":
+				start = m[2]
+			case "endDate":
+				end = m[2]
+			case "currencyNames":
+				currencies = append(currencies, strings.Split(m[2], ",")...)
+			}
+		}
+		var buf strings.Builder
+		buf.WriteString(`<MNBExchangeRates>`)
+		for _, d := range days {
+			if s := d.Day.String(); s < start || s > end {
+				continue
+			}
+			fmt.Fprintf(&buf, `<Day date="%s">`, d.Day)
+			for _, r := range d.Rates {
+				if slices.Contains(currencies, r.Currency) {
+					fmt.Fprintf(&buf, `<Rate unit="%d" curr="%s">%s</Rate>`,
+						r.Unit, r.Currency, strings.ReplaceAll(r.Rate.String(), ".", ","))
+				}
+			}
+			buf.WriteString(`</Day>`)
+		}
+		buf.WriteString(`</MNBExchangeRates>`)
+		w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+		fmt.Fprintf(w, `<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/"><s:Body><%sResponse xmlns="http://www.mnb.hu/webservices/"><%sResult><![CDATA[%s]]></%sResult></%sResponse></s:Body></s:Envelope>`,
+			name, name, buf.String(), name, name)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// serveFakeECB starts a fake of the ECB feed, serving the days ("day CUR=rate ..." lines, newest first, like the feed).
+// The server is closed at the end of the test; calls counts the requests.
+func serveFakeECB(t *testing.T, calls *atomic.Int32, lines ...string) *httptest.Server {
+	t.Helper()
+	var buf strings.Builder
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+<gesmes:subject>Reference rates</gesmes:subject>
+<Cube>`)
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		fmt.Fprintf(&buf, "\n<Cube time=%q>", fields[0])
+		for _, f := range fields[1:] {
+			c, r, _ := strings.Cut(f, "=")
+			fmt.Fprintf(&buf, "<Cube currency=%q rate=%q/>", c, r)
+		}
+		buf.WriteString("</Cube>")
+	}
+	buf.WriteString("\n</Cube>\n</gesmes:Envelope>\n")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls != nil {
+			calls.Add(1)
+		}
+		w.Header().Set("Content-Type", "text/xml")
+		io.WriteString(w, buf.String())
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
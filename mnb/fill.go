@@ -0,0 +1,69 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mnb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+)
+
+// FilledDayRates is the rates of a calendar day: the rates of Source,
+// which is the Day itself on publication days, else the last publication day before it.
+type FilledDayRates struct {
+	DayRates
+	Source Date
+}
+
+// Filled reports whether the rates come from an earlier publication day.
+func (f FilledDayRates) Filled() bool { return !time.Time(f.Source).Equal(time.Time(f.Day)) }
+
+// Fill returns the rates of every calendar day from begin to end (inclusive), in ascending order,
+// carrying the last published rates forward.
+// The days may contain publication days before begin, to fill the first days of the period;
+// the days before the first publication are omitted.
+func Fill(days []DayRates, begin, end time.Time) []FilledDayRates {
+	days = slices.Clone(days)
+	slices.SortFunc(days, func(a, b DayRates) int { return time.Time(a.Day).Compare(time.Time(b.Day)) })
+	begin = time.Date(begin.Year(), begin.Month(), begin.Day(), 0, 0, 0, 0, time.UTC)
+	end = time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+	var filled []FilledDayRates
+	var last *DayRates
+	i := 0
+	for t := begin; !t.After(end); t = t.AddDate(0, 0, 1) {
+		for i < len(days) && !time.Time(days[i].Day).After(t) {
+			last = &days[i]
+			i++
+		}
+		if last == nil {
+			continue
+		}
+		filled = append(filled, FilledDayRates{
+			DayRates: DayRates{Day: Date(t), Rates: slices.Clip(last.Rates)},
+			Source:   last.Day,
+		})
+	}
+	return filled
+}
+
+// GetFilledExchangeRates returns the rates of every calendar day of the period, see Fill.
+// The rates of the first days come from the last publication before begin.
+func (m MNBArfolyamService) GetFilledExchangeRates(ctx context.Context, begin, end time.Time, currencies ...string) ([]FilledDayRates, error) {
+	days, err := m.GetExchangeRates(ctx, begin, end, currencies...)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.ContainsFunc(days, func(d DayRates) bool { return dayOf(time.Time(d.Day)).Equal(dayOf(begin)) }) {
+		prev, err := m.GetExchangeRatesAt(ctx, begin, currencies...)
+		if err != nil && !errors.Is(err, ErrNoRate) {
+			return nil, err
+		}
+		if err == nil {
+			days = append(days, prev)
+		}
+	}
+	return Fill(days, begin, end), nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mnb
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFill(t *testing.T) {
+	// unordered, with a publication before the period
+	days := testDays(t,
+		"2024-05-06 EUR=392",
+		"2024-04-30 EUR=389",
+		"2024-05-03 EUR=390",
+		"2024-05-02 EUR=391",
+	)
+	for _, tc := range []struct {
+		Name       string
+		Begin, End string
+		// Want is the "day<source" list, with the EUR rate.
+		Want string
+	}{
+		{Name: "long weekend", Begin: "2024-04-30", End: "2024-05-07",
+			Want: "04-30<04-30=389 05-01<04-30=389 05-02<05-02=391 05-03<05-03=390 " +
+				"05-04<05-03=390 05-05<05-03=390 05-06<05-06=392 05-07<05-06=392"},
+		{Name: "from the last publication before begin", Begin: "2024-05-04", End: "2024-05-06",
+			Want: "05-04<05-03=390 05-05<05-03=390 05-06<05-06=392"},
+		{Name: "before the first publication", Begin: "2024-04-28", End: "2024-05-01",
+			Want: "04-30<04-30=389 05-01<04-30=389"},
+		{Name: "one day", Begin: "2024-05-05", End: "2024-05-05", Want: "05-05<05-03=390"},
+		{Name: "empty period", Begin: "2024-05-06", End: "2024-05-05"},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			// the time of day doesn't matter
+			begin := parseTestDay(t, tc.Begin).Add(15 * time.Hour)
+			end := parseTestDay(t, tc.End).Add(time.Hour)
+			var got []string
+			for _, f := range Fill(days, begin, end) {
+				r, _ := f.Find("EUR")
+				got = append(got, fmt.Sprintf("%s<%s=%s",
+					f.Day.String()[5:], f.Source.String()[5:], r.Rate.String()))
+				if f.Filled() == time.Time(f.Day).Equal(time.Time(f.Source)) {
+					t.Errorf("%s: got filled %t", f.Day, f.Filled())
+				}
+			}
+			if got := strings.Join(got, " "); got != tc.Want {
+				t.Errorf("got %s,\nwanted %s", got, tc.Want)
+			}
+		})
+	}
+	if got := days[0].Day.String(); got != "2024-05-06" {
+		t.Errorf("Fill reordered the days: the first is %s", got)
+	}
+	if got := Fill(nil, parseTestDay(t, "2024-05-01"), parseTestDay(t, "2024-05-02")); len(got) != 0 {
+		t.Errorf("got %v from no days", got)
+	}
+}
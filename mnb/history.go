@@ -0,0 +1,83 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mnb
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+)
+
+// History is the publication days in ascending order, to look up the rates in effect on any day.
+type History []DayRates
+
+// NewHistory returns the days as a History.
+func NewHistory(days []DayRates) History {
+	h := History(slices.Clone(days))
+	slices.SortFunc(h, func(a, b DayRates) int { return time.Time(a.Day).Compare(time.Time(b.Day)) })
+	return h
+}
+
+// At returns the rates of the day, or of the last publication day before it.
+func (h History) At(t time.Time) (DayRates, bool) {
+	t = dayOf(t)
+	i := sort.Search(len(h), func(i int) bool { return time.Time(h[i].Day).After(t) })
+	if i == 0 {
+		return DayRates{}, false
+	}
+	return h[i-1], true
+}
+
+// RateAt returns the rate of the currency in effect on the day, and the day it was published on.
+func (h History) RateAt(t time.Time, currency string) (Rate, Date, error) {
+	if currency == "HUF" {
+		r, _ := DayRates{}.Find(currency)
+		return r, Date(dayOf(t)), nil
+	}
+	d, ok := h.At(t)
+	if ok {
+		if r, ok := d.Find(currency); ok {
+			return r, d.Day, nil
+		}
+	}
+	return Rate{}, Date{}, fmt.Errorf("%s on %s: %w", currency, t.Format("2006-01-02"), ErrNoRate)
+}
+
+// GetHistory returns the History of the period, including the last publication day before begin,
+// with one GetExchangeRates call.
+func (m MNBArfolyamService) GetHistory(ctx context.Context, begin, end time.Time, currencies ...string) (History, error) {
+	// HUF is not published, but always found
+	if currencies = slices.DeleteFunc(slices.Clone(currencies), func(c string) bool { return c == "HUF" }); len(currencies) == 0 {
+		return nil, nil
+	}
+	days, err := m.GetExchangeRates(ctx, begin.AddDate(0, 0, -fallbackDays), end, currencies...)
+	if err != nil {
+		return nil, err
+	}
+	return NewHistory(days), nil
+}
+
+// Convert converts the amount from one currency to the other, through HUF,
+// with the rates in effect on the day. It returns the converted amount, the rate
+// (the price of one unit of from, in to) and the publication day of the rates.
+func (h History) Convert(t time.Time, amount Double, from, to string) (value, rate Double, day Date, err error) {
+	if from == to {
+		return amount, NewDouble(1, 0), Date(dayOf(t)), nil
+	}
+	d, ok := h.At(t)
+	if !ok {
+		if from != "HUF" {
+			return value, rate, day, fmt.Errorf("%s on %s: %w", from, t.Format("2006-01-02"), ErrNoRate)
+		}
+		return value, rate, day, fmt.Errorf("%s on %s: %w", to, t.Format("2006-01-02"), ErrNoRate)
+	}
+	if rate, err = d.CrossRate(from, to); err != nil {
+		return value, rate, day, err
+	}
+	value, err = amount.Mul(rate)
+	return value, rate, d.Day, err
+}
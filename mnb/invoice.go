@@ -0,0 +1,129 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mnb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Sources of the invoice exchange rates.
+const (
+	SourceMNB = "MNB"
+	SourceECB = "ECB"
+)
+
+// InvoiceRatePlaces is the number of decimal places of the exchangeRate field of the NAV Online Invoice.
+const InvoiceRatePlaces = 6
+
+// InvoiceRate is a candidate exchange rate of an invoice, by the Hungarian VAT Act:
+// the MNB rate, or the ECB rate converted through EUR, in effect on the day of supply.
+type InvoiceRate struct {
+	// Source is SourceMNB or SourceECB.
+	Source   string
+	Currency string
+	// SupplyDay is the day of supply, Day is the publication day of the rate:
+	// the day of supply, or the last publication day before it.
+	SupplyDay, Day Date
+	// ExchangeRate is the HUF value of one unit of the currency, rounded to InvoiceRatePlaces,
+	// as the NAV Online Invoice exchangeRate field expects.
+	ExchangeRate Double
+	// Quote is the published rates the ExchangeRate comes from.
+	Quote string
+	// Rule is the applied rule, to be documented by the invoicing system.
+	Rule string
+}
+
+// invoiceRate returns the rate of the currency in the History of the source, in effect on the day of supply.
+func invoiceRate(h History, source, currency string, supply time.Time, quote func(DayRates) string) (InvoiceRate, error) {
+	supply = dayOf(supply)
+	ir := InvoiceRate{Source: source, Currency: currency, SupplyDay: Date(supply)}
+	r, day, err := h.RateAt(supply, currency)
+	if err != nil {
+		return ir, fmt.Errorf("%s: %w", source, err)
+	}
+	rate, err := r.PerUnit()
+	if err != nil {
+		return ir, fmt.Errorf("%s: %w", source, err)
+	}
+	ir.Day, ir.ExchangeRate = day, rate.Round(InvoiceRatePlaces)
+	if currency == "HUF" {
+		ir.Rule = "the invoice is in HUF"
+		return ir, nil
+	}
+	if d, ok := h.At(supply); ok {
+		ir.Quote = quote(d)
+	}
+	what := "the MNB official exchange rate"
+	if source == SourceECB {
+		what = "the ECB reference rate converted through EUR"
+	}
+	if time.Time(day).Equal(supply) {
+		ir.Rule = fmt.Sprintf("%s, published on the day of supply (%s)", what, day)
+	} else {
+		ir.Rule = fmt.Sprintf("%s of the last publication day (%s) before the day of supply (%s)", what, day, ir.SupplyDay)
+	}
+	return ir, nil
+}
+
+// InvoiceRates returns the candidate exchange rates of an invoice in the currency with the day of supply:
+// the MNB rate and the ECB reference rate converted through EUR (EUR/HUF divided by EUR/currency),
+// published on the day of supply, or the last publication day before it.
+//
+// It returns the rates available, and the errors of the unavailable ones.
+// For HUF, it returns only the MNB rate, which is 1.
+func (m MNBArfolyamService) InvoiceRates(ctx context.Context, ecb ECB, currency string, supply time.Time) ([]InvoiceRate, error) {
+	supply = dayOf(supply)
+	var rates []InvoiceRate
+	var errs []error
+
+	if h, err := m.GetHistory(ctx, supply, supply, currency); err != nil {
+		errs = append(errs, fmt.Errorf("%s: %w", SourceMNB, err))
+	} else if ir, err := invoiceRate(h, SourceMNB, currency, supply, func(d DayRates) string {
+		r, _ := d.Find(currency)
+		return fmt.Sprintf("%d %s = %s HUF", r.Unit, currency, r.Rate.String())
+	}); err != nil {
+		errs = append(errs, err)
+	} else {
+		rates = append(rates, ir)
+	}
+
+	if currency == "HUF" {
+		// the exchange rate is 1, no matter the source
+		return rates, errors.Join(errs...)
+	}
+	ecbDays, err := ecb.GetRates(ctx, supply.AddDate(0, 0, -fallbackDays))
+	if err != nil {
+		return rates, errors.Join(append(errs, fmt.Errorf("%s: %w", SourceECB, err))...)
+	}
+	days := make([]DayRates, 0, len(ecbDays))
+	quotes := make(map[Date]string, len(ecbDays))
+	for _, d := range ecbDays {
+		if time.Time(d.Day).After(supply) {
+			continue
+		}
+		day, err := d.HUFRates()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", SourceECB, err))
+			continue
+		}
+		days = append(days, day)
+		quote := fmt.Sprintf("1 EUR = %s HUF", d.Rates["HUF"].String())
+		if r, ok := d.Rates[currency]; ok && currency != "EUR" {
+			quote += fmt.Sprintf(", 1 EUR = %s %s", r.String(), currency)
+		}
+		quotes[d.Day] = quote
+	}
+	if ir, err := invoiceRate(NewHistory(days), SourceECB, currency, supply, func(d DayRates) string {
+		return quotes[d.Day]
+	}); err != nil {
+		errs = append(errs, err)
+	} else {
+		rates = append(rates, ir)
+	}
+	return rates, errors.Join(errs...)
+}
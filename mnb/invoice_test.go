@@ -0,0 +1,131 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mnb
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestInvoiceRates(t *testing.T) {
+	// 2024-03-15 is a Hungarian public holiday, but an ECB publication day
+	var mnbCalls, ecbCalls atomic.Int32
+	m := NewMNBArfolyamService(serveFakeMNB(t, testDays(t,
+		"2024-03-13 EUR=393.10 USD=360.10",
+		"2024-03-14 EUR=393.50 USD=361.00",
+		"2024-03-18 EUR=394.00 USD=362.00",
+	), &mnbCalls).URL, nil, nil)
+	ecb := NewECB(serveFakeECB(t, &ecbCalls,
+		"2024-03-18 USD=1.0875 HUF=394.10",
+		"2024-03-15 USD=1.0887 HUF=393.60",
+		"2024-03-14 USD=1.0890 HUF=393.20",
+	).URL, nil, nil)
+
+	type rateWant struct{ Source, Day, Rate, Quote, Rule string }
+	for _, tc := range []struct {
+		Currency, Supply string
+		Want             []rateWant
+	}{
+		{Currency: "USD", Supply: "2024-03-18", Want: []rateWant{
+			{SourceMNB, "2024-03-18", "362", "1 USD = 362.00 HUF", "published on the day of supply (2024-03-18)"},
+			{SourceECB, "2024-03-18", "362.390805", "1 EUR = 394.10 HUF, 1 EUR = 1.0875 USD", "published on the day of supply (2024-03-18)"},
+		}},
+		{Currency: "USD", Supply: "2024-03-15", Want: []rateWant{
+			{SourceMNB, "2024-03-14", "361", "1 USD = 361.00 HUF", "of the last publication day (2024-03-14) before the day of supply (2024-03-15)"},
+			{SourceECB, "2024-03-15", "361.532103", "1 EUR = 393.60 HUF, 1 EUR = 1.0887 USD", "published on the day of supply (2024-03-15)"},
+		}},
+		{Currency: "USD", Supply: "2024-03-17", Want: []rateWant{ // Sunday
+			{SourceMNB, "2024-03-14", "361", "1 USD = 361.00 HUF", "of the last publication day (2024-03-14) before the day of supply (2024-03-17)"},
+			{SourceECB, "2024-03-15", "361.532103", "1 EUR = 393.60 HUF, 1 EUR = 1.0887 USD", "of the last publication day (2024-03-15) before the day of supply (2024-03-17)"},
+		}},
+		{Currency: "EUR", Supply: "2024-03-16", Want: []rateWant{
+			{SourceMNB, "2024-03-14", "393.5", "1 EUR = 393.50 HUF", "MNB official exchange rate of the last publication day (2024-03-14)"},
+			{SourceECB, "2024-03-15", "393.6", "1 EUR = 393.60 HUF", "ECB reference rate converted through EUR of the last publication day (2024-03-15)"},
+		}},
+	} {
+		rates, err := m.InvoiceRates(context.Background(), ecb, tc.Currency, parseTestDay(t, tc.Supply))
+		if err != nil {
+			t.Errorf("%s %s: %+v", tc.Currency, tc.Supply, err)
+			continue
+		}
+		if len(rates) != len(tc.Want) {
+			t.Errorf("%s %s: got %d rates, wanted %d", tc.Currency, tc.Supply, len(rates), len(tc.Want))
+			continue
+		}
+		for i, want := range tc.Want {
+			ir := rates[i]
+			name := tc.Currency + " " + tc.Supply + " " + want.Source
+			if ir.Source != want.Source || ir.Currency != tc.Currency || ir.SupplyDay.String() != tc.Supply {
+				t.Errorf("%s: got %s %s %s", name, ir.Source, ir.Currency, ir.SupplyDay)
+			}
+			if got := ir.Day.String(); got != want.Day {
+				t.Errorf("%s: got the rate of %s, wanted %s", name, got, want.Day)
+			}
+			checkDouble(t, name, ir.ExchangeRate, want.Rate)
+			if ir.Quote != want.Quote {
+				t.Errorf("%s: got quote %q, wanted %q", name, ir.Quote, want.Quote)
+			}
+			if !strings.Contains(ir.Rule, want.Rule) {
+				t.Errorf("%s: got rule %q, wanted %q", name, ir.Rule, want.Rule)
+			}
+		}
+	}
+
+	mnbCalls.Store(0)
+	ecbCalls.Store(0)
+	rates, err := m.InvoiceRates(context.Background(), ecb, "HUF", parseTestDay(t, "2024-03-16"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rates) != 1 || rates[0].Source != SourceMNB {
+		t.Fatalf("HUF: got %+v, wanted the MNB rate only", rates)
+	}
+	checkDouble(t, "HUF", rates[0].ExchangeRate, "1")
+	if got := rates[0].Day.String(); got != "2024-03-16" {
+		t.Errorf("HUF: got the rate of %s, wanted the day of supply", got)
+	}
+	if n, m := mnbCalls.Load(), ecbCalls.Load(); n != 0 || m != 0 {
+		t.Errorf("HUF: got %d MNB and %d ECB calls, wanted none", n, m)
+	}
+}
+
+func TestInvoiceRatesMissing(t *testing.T) {
+	m := NewMNBArfolyamService(serveFakeMNB(t, testDays(t,
+		"2024-03-14 EUR=393.50 USD=361.00",
+	), nil).URL, nil, nil)
+	ecb := NewECB(serveFakeECB(t, nil,
+		"2024-03-15 USD=1.0887",
+		"2024-03-14 USD=1.0890 HUF=393.20",
+	).URL, nil, nil)
+
+	// GBP is published by neither
+	rates, err := m.InvoiceRates(context.Background(), ecb, "GBP", parseTestDay(t, "2024-03-15"))
+	if len(rates) != 0 {
+		t.Errorf("GBP: got %+v", rates)
+	}
+	if !errors.Is(err, ErrNoRate) {
+		t.Errorf("GBP: got %+v, wanted %v", err, ErrNoRate)
+	}
+	for _, source := range []string{SourceMNB + ": ", SourceECB + ": "} {
+		if err == nil || !strings.Contains(err.Error(), source) {
+			t.Errorf("GBP: no %s error in %v", source, err)
+		}
+	}
+
+	// the ECB publication without HUF is skipped, with its error
+	rates, err = m.InvoiceRates(context.Background(), ecb, "USD", parseTestDay(t, "2024-03-15"))
+	if len(rates) != 2 {
+		t.Fatalf("USD: got %+v (%+v)", rates, err)
+	}
+	if got := rates[1].Day.String(); got != "2024-03-14" {
+		t.Errorf("USD: got the ECB rate of %s, wanted 2024-03-14", got)
+	}
+	if !errors.Is(err, ErrNoRate) || !strings.Contains(err.Error(), "HUF on 2024-03-15") {
+		t.Errorf("USD: got %+v, wanted the missing HUF of 2024-03-15", err)
+	}
+}
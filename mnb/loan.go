@@ -0,0 +1,267 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mnb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/apd/v3"
+)
+
+// Amortization is how the principal of a loan is repaid.
+type Amortization string
+
+const (
+	// Annuity repays with equal payments, recomputed when the interest rate changes.
+	Annuity = Amortization("annuity")
+	// Linear repays equal parts of the principal, with the interest on top.
+	Linear = Amortization("linear")
+)
+
+// ParseAmortization parses the amortization name (annuity or linear).
+func ParseAmortization(s string) (Amortization, error) {
+	a := Amortization(strings.ToLower(strings.TrimSpace(s)))
+	switch a {
+	case Annuity, Linear:
+		return a, nil
+	}
+	return a, fmt.Errorf("unknown amortization %q (annuity or linear is accepted)", s)
+}
+
+// Loan is a variable-rate loan with an interest rate of the MNB base rate plus Margin.
+type Loan struct {
+	Principal Double
+	// Margin is added to the base rate, in percent.
+	Margin Double
+	// Start is the day of the disbursement.
+	Start Date
+	// Frequency is the length of the payment periods: Month, Quarter or Year.
+	Frequency Period
+	// Term is the number of payments.
+	Term         int
+	Amortization Amortization
+}
+
+// LoanPeriod is a payment period of a loan schedule.
+type LoanPeriod struct {
+	N          int
+	Start, End Date
+	// BaseRate is the base rate in effect on Start, Projected tells whether it comes from the projection.
+	BaseRate  Double
+	Projected bool
+	// Rate is the annual interest rate of the period in percent: BaseRate + Margin.
+	Rate Double
+	// Opening and Closing are the principal at the start and at the end of the period,
+	// Payment = Interest + Principal is paid at the End.
+	Opening, Interest, Principal, Payment, Closing Double
+}
+
+// LoanSchedule is the payment schedule of a Loan.
+type LoanSchedule struct {
+	Loan
+	Periods                     []LoanPeriod
+	TotalInterest, TotalPayment Double
+}
+
+// monthsOf returns the number of months in the period.
+func monthsOf(p Period) (int, error) {
+	switch p {
+	case Month:
+		return 1, nil
+	case Quarter:
+		return 3, nil
+	case Year:
+		return 12, nil
+	}
+	return 0, fmt.Errorf("payment frequency %q is not supported (month, quarter or year is accepted)", p)
+}
+
+// ParseLoanFrequency parses the payment frequency of a loan: month, quarter or year.
+func ParseLoanFrequency(s string) (Period, error) {
+	p, err := ParsePeriod(s)
+	if err == nil {
+		_, err = monthsOf(p)
+	}
+	if err != nil {
+		return p, fmt.Errorf("payment frequency %q is not supported (month, quarter or year is accepted)", s)
+	}
+	return p, nil
+}
+
+// addMonths returns the same day n months later, or the last day of that month if it's shorter.
+func addMonths(t time.Time, n int) time.Time {
+	y, m, d := t.Date()
+	last := ymd(y, m+time.Month(n)+1, 0).Day()
+	return ymd(y, m+time.Month(n), min(d, last))
+}
+
+// Schedule computes the payment schedule of the loan.
+//
+// The interest rate of each period is the base rate in effect on its first day plus the Margin;
+// the base rates come from the history, and after its last publication, from the projection
+// (if there's no projection, the last published base rate stays in effect).
+// The interest of a period is the opening principal times the annual rate divided by the periods in a year.
+// The annuity payment is recomputed for the remaining term whenever the rate changes.
+// The HUF amounts are rounded to places decimals, the last payment repays the remaining principal.
+func (loan Loan) Schedule(history, projection []MNBBaseRate, places int32) (LoanSchedule, error) {
+	sched := LoanSchedule{Loan: loan}
+	months, err := monthsOf(loan.Frequency)
+	if err != nil {
+		return sched, err
+	}
+	if loan.Term <= 0 {
+		return sched, errors.New("the term must be positive")
+	}
+	if loan.Principal.Decimal == nil || loan.Principal.Sign() <= 0 {
+		return sched, errors.New("the principal must be positive")
+	}
+	rates := newBaseRateTimeline(history, projection)
+	perYear := apd.New(int64(12/months), 0)
+	start := time.Time(loan.Start)
+	balance := loan.Principal
+	var payment, lastRate Double
+	sched.TotalInterest, sched.TotalPayment = NewDouble(0, 0), NewDouble(0, 0)
+	for n := 1; n <= loan.Term; n++ {
+		p := LoanPeriod{N: n, Start: Date(addMonths(start, (n-1)*months)), End: Date(addMonths(start, n*months)), Opening: balance}
+		br, projected, ok := rates.at(time.Time(p.Start))
+		if !ok {
+			return sched, fmt.Errorf("no base rate on %s: %w", p.Start, ErrNoRate)
+		}
+		p.BaseRate, p.Projected = br, projected
+		if p.Rate, err = br.Add(loan.Margin); err != nil {
+			return sched, err
+		}
+		// the periodic rate
+		r, err := p.Rate.Quo(Double{Decimal: apd.New(100, 0)})
+		if err == nil {
+			r, err = r.Quo(Double{Decimal: perYear})
+		}
+		if err != nil {
+			return sched, err
+		}
+		interest, err := balance.Mul(r)
+		if err != nil {
+			return sched, err
+		}
+		p.Interest = interest.Round(places)
+
+		remaining := loan.Term - n + 1
+		switch {
+		case n == loan.Term:
+			p.Principal = balance
+		case loan.Amortization == Linear:
+			principal, err := balance.Quo(Double{Decimal: apd.New(int64(remaining), 0)})
+			if err != nil {
+				return sched, err
+			}
+			p.Principal = principal.Round(places)
+		default:
+			if payment.Decimal == nil || lastRate.Cmp(p.Rate.Decimal) != 0 {
+				if payment, err = annuityPayment(balance, r, remaining); err != nil {
+					return sched, err
+				}
+				payment = payment.Round(places)
+			}
+			if p.Principal, err = payment.Sub(p.Interest); err != nil {
+				return sched, err
+			}
+		}
+		lastRate = p.Rate
+		if p.Payment, err = p.Interest.Add(p.Principal); err != nil {
+			return sched, err
+		}
+		if p.Closing, err = balance.Sub(p.Principal); err != nil {
+			return sched, err
+		}
+		balance = p.Closing
+		if sched.TotalInterest, err = sched.TotalInterest.Add(p.Interest); err != nil {
+			return sched, err
+		}
+		if sched.TotalPayment, err = sched.TotalPayment.Add(p.Payment); err != nil {
+			return sched, err
+		}
+		sched.Periods = append(sched.Periods, p)
+	}
+	return sched, nil
+}
+
+// annuityPayment returns the equal payment repaying the principal in n periods at the periodic rate r:
+// principal * r / (1 - (1+r)^-n).
+func annuityPayment(principal, r Double, n int) (Double, error) {
+	if r.Sign() == 0 {
+		return principal.Quo(Double{Decimal: apd.New(int64(n), 0)})
+	}
+	var q, one apd.Decimal
+	one.SetInt64(1)
+	if _, err := DecimalContext.Add(&q, &one, r.Decimal); err != nil {
+		return Double{}, err
+	}
+	if _, err := DecimalContext.Pow(&q, &q, apd.New(int64(-n), 0)); err != nil {
+		return Double{}, err
+	}
+	if _, err := DecimalContext.Sub(&q, &one, &q); err != nil {
+		return Double{}, err
+	}
+	a, err := principal.Mul(r)
+	if err != nil {
+		return Double{}, err
+	}
+	return a.Quo(Double{Decimal: &q})
+}
+
+// projectedBaseRate is a base rate, which may come from a projection.
+type projectedBaseRate struct {
+	MNBBaseRate
+	Projected bool
+}
+
+// baseRateTimeline is the base rates in ascending publication order.
+type baseRateTimeline []projectedBaseRate
+
+// newBaseRateTimeline returns the history, followed by the projected rates after its last publication.
+func newBaseRateTimeline(history, projection []MNBBaseRate) baseRateTimeline {
+	tl := make(baseRateTimeline, 0, len(history)+len(projection))
+	var last time.Time
+	for _, r := range history {
+		tl = append(tl, projectedBaseRate{MNBBaseRate: r})
+		if t := time.Time(r.Publication); t.After(last) {
+			last = t
+		}
+	}
+	for _, r := range projection {
+		if time.Time(r.Publication).After(last) {
+			tl = append(tl, projectedBaseRate{MNBBaseRate: r, Projected: true})
+		}
+	}
+	slices.SortStableFunc(tl, func(a, b projectedBaseRate) int {
+		return time.Time(a.Publication).Compare(time.Time(b.Publication))
+	})
+	return tl
+}
+
+// at returns the base rate in effect on the day.
+func (tl baseRateTimeline) at(t time.Time) (Double, bool, bool) {
+	for i := len(tl) - 1; i >= 0; i-- {
+		if !time.Time(tl[i].Publication).After(t) {
+			return tl[i].Rate, tl[i].Projected, true
+		}
+	}
+	return Double{}, false, false
+}
+
+// LoanSchedule returns the payment schedule of the loan (see Loan.Schedule),
+// with the base rate history from MNB, and the projection for the future.
+func (m MNBAlapkamatService) LoanSchedule(ctx context.Context, loan Loan, projection []MNBBaseRate, places int32) (LoanSchedule, error) {
+	history, err := m.GetCentralBankBaseRate(ctx, time.Time{}, time.Now())
+	if err != nil {
+		return LoanSchedule{Loan: loan}, err
+	}
+	return loan.Schedule(history, projection, places)
+}
@@ -0,0 +1,168 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mnb
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// testBaseRates returns the base rates from "day=rate" pairs.
+func testBaseRates(t *testing.T, pairs ...string) []MNBBaseRate {
+	t.Helper()
+	rates := make([]MNBBaseRate, 0, len(pairs))
+	for _, p := range pairs {
+		day, rate, _ := strings.Cut(p, "=")
+		rates = append(rates, MNBBaseRate{Publication: Date(parseTestDay(t, day)), Rate: testDouble(t, rate)})
+	}
+	return rates
+}
+
+func TestLoanSchedule(t *testing.T) {
+	type periodWant struct {
+		End                                   string
+		Rate                                  string
+		Projected                             bool
+		Interest, Principal, Payment, Closing string
+	}
+	for _, tc := range []struct {
+		Name                        string
+		Loan                        Loan
+		History, Projection         []MNBBaseRate
+		Periods                     []periodWant
+		TotalInterest, TotalPayment string
+	}{
+		{Name: "linear",
+			Loan:    Loan{Principal: testDouble(t, "1200"), Margin: testDouble(t, "0"), Frequency: Month, Term: 3, Amortization: Linear},
+			History: testBaseRates(t, "2023-12-01=12"),
+			Periods: []periodWant{
+				{"2024-02-15", "12", false, "12", "400", "412", "800"},
+				{"2024-03-15", "12", false, "8", "400", "408", "400"},
+				{"2024-04-15", "12", false, "4", "400", "404", "0"},
+			},
+			TotalInterest: "24", TotalPayment: "1224"},
+		{Name: "linear with a base rate change",
+			Loan:    Loan{Principal: testDouble(t, "1200"), Margin: testDouble(t, "2"), Frequency: Month, Term: 3, Amortization: Linear},
+			History: testBaseRates(t, "2023-12-01=10", "2024-02-20=22"),
+			Periods: []periodWant{
+				{"2024-02-15", "12", false, "12", "400", "412", "800"},
+				// the change is in effect from the start of the next period
+				{"2024-03-15", "12", false, "8", "400", "408", "400"},
+				{"2024-04-15", "24", false, "8", "400", "408", "0"},
+			},
+			TotalInterest: "28", TotalPayment: "1228"},
+		{Name: "annuity",
+			Loan:    Loan{Principal: testDouble(t, "1000"), Margin: testDouble(t, "0"), Frequency: Month, Term: 2, Amortization: Annuity},
+			History: testBaseRates(t, "2023-12-01=12"),
+			Periods: []periodWant{
+				{"2024-02-15", "12", false, "10", "497.51", "507.51", "502.49"},
+				// the last payment repays the rest
+				{"2024-03-15", "12", false, "5.02", "502.49", "507.51", "0"},
+			},
+			TotalInterest: "15.02", TotalPayment: "1015.02"},
+		{Name: "annuity with a projected change",
+			Loan:    Loan{Principal: testDouble(t, "1200"), Margin: testDouble(t, "0"), Frequency: Month, Term: 3, Amortization: Annuity},
+			History: testBaseRates(t, "2023-12-01=12"),
+			// the projection before the last published rate is ignored
+			Projection: testBaseRates(t, "2023-11-15=99", "2024-02-01=24"),
+			Periods: []periodWant{
+				{"2024-02-15", "12", false, "12", "396.03", "408.03", "803.97"},
+				// recomputed for the remaining 2 periods
+				{"2024-03-15", "24", true, "16.08", "398", "414.08", "405.97"},
+				{"2024-04-15", "24", true, "8.12", "405.97", "414.09", "0"},
+			},
+			TotalInterest: "36.20", TotalPayment: "1236.20"},
+		{Name: "quarterly",
+			Loan:    Loan{Principal: testDouble(t, "1000"), Margin: testDouble(t, "0"), Frequency: Quarter, Term: 2, Amortization: Linear},
+			History: testBaseRates(t, "2023-12-01=8"),
+			Periods: []periodWant{
+				{"2024-04-15", "8", false, "20", "500", "520", "500"},
+				{"2024-07-15", "8", false, "10", "500", "510", "0"},
+			},
+			TotalInterest: "30", TotalPayment: "1030"},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			tc.Loan.Start = Date(parseTestDay(t, "2024-01-15"))
+			sched, err := tc.Loan.Schedule(tc.History, tc.Projection, 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(sched.Periods) != len(tc.Periods) {
+				t.Fatalf("got %d periods, wanted %d", len(sched.Periods), len(tc.Periods))
+			}
+			for i, want := range tc.Periods {
+				p := sched.Periods[i]
+				if got := p.End.String(); got != want.End {
+					t.Errorf("%d. got end %s, wanted %s", p.N, got, want.End)
+				}
+				if p.Projected != want.Projected {
+					t.Errorf("%d. got projected %t, wanted %t", p.N, p.Projected, want.Projected)
+				}
+				checkDouble(t, "rate", p.Rate, want.Rate)
+				checkDouble(t, "interest", p.Interest, want.Interest)
+				checkDouble(t, "principal", p.Principal, want.Principal)
+				checkDouble(t, "payment", p.Payment, want.Payment)
+				checkDouble(t, "closing", p.Closing, want.Closing)
+			}
+			checkDouble(t, "total interest", sched.TotalInterest, tc.TotalInterest)
+			checkDouble(t, "total payment", sched.TotalPayment, tc.TotalPayment)
+		})
+	}
+}
+
+func TestLoanScheduleMonthEnd(t *testing.T) {
+	loan := Loan{Principal: testDouble(t, "300"), Margin: testDouble(t, "0"),
+		Start: Date(parseTestDay(t, "2024-01-31")), Frequency: Month, Term: 3, Amortization: Linear}
+	sched, err := loan.Schedule(testBaseRates(t, "2023-12-01=12"), nil, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ends []string
+	for _, p := range sched.Periods {
+		ends = append(ends, p.End.String())
+	}
+	if got, want := strings.Join(ends, " "), "2024-02-29 2024-03-31 2024-04-30"; got != want {
+		t.Errorf("got %s, wanted %s", got, want)
+	}
+}
+
+func TestLoanScheduleErrors(t *testing.T) {
+	history := testBaseRates(t, "2024-01-01=12")
+	for _, tc := range []struct {
+		Name string
+		Loan Loan
+		Want string
+	}{
+		{"weekly", Loan{Principal: testDouble(t, "100"), Frequency: Week, Term: 1}, `payment frequency "week" is not supported`},
+		{"no term", Loan{Principal: testDouble(t, "100"), Frequency: Month}, "the term must be positive"},
+		{"no principal", Loan{Principal: testDouble(t, "0"), Frequency: Month, Term: 1}, "the principal must be positive"},
+		{"before the first base rate", Loan{Principal: testDouble(t, "100"), Margin: testDouble(t, "0"), Frequency: Month, Term: 1,
+			Start: Date(parseTestDay(t, "2023-12-31"))}, "no base rate on 2023-12-31"},
+	} {
+		_, err := tc.Loan.Schedule(history, nil, 2)
+		if err == nil {
+			t.Errorf("%s: no error", tc.Name)
+		} else if !strings.Contains(err.Error(), tc.Want) {
+			t.Errorf("%s: got %q, wanted %q", tc.Name, err.Error(), tc.Want)
+		}
+	}
+	if _, err := (Loan{Principal: testDouble(t, "100"), Frequency: Month, Term: 1}).Schedule(nil, nil, 2); !errors.Is(err, ErrNoRate) {
+		t.Errorf("without base rates: got %+v, wanted %v", err, ErrNoRate)
+	}
+}
+
+func TestParseLoanFrequency(t *testing.T) {
+	for s, want := range map[string]Period{"month": Month, "quarter": Quarter, "year": Year} {
+		if got, err := ParseLoanFrequency(s); err != nil || got != want {
+			t.Errorf("%s: got %q, %+v", s, got, err)
+		}
+	}
+	for _, s := range []string{"week", "day", ""} {
+		if _, err := ParseLoanFrequency(s); err == nil {
+			t.Errorf("%q: no error", s)
+		}
+	}
+}
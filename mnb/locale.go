@@ -0,0 +1,109 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mnb
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// Locale holds the number and date formatting conventions of a language.
+//
+// The zero Locale formats as String does: decimal dot, no grouping, ISO dates.
+type Locale struct {
+	Tag        string
+	Decimal    string
+	Group      string
+	DateLayout string
+}
+
+// dateLayouts are the CLDR short numeric date patterns (zero padded, four digit year).
+var dateLayouts = map[string]string{
+	"bg": "02.01.2006", "cs": "02. 01. 2006", "da": "02.01.2006",
+	"de": "02.01.2006", "el": "02/01/2006", "en": "01/02/2006",
+	"en-AU": "02/01/2006", "en-GB": "02/01/2006", "en-IE": "02/01/2006",
+	"en-NZ": "02/01/2006", "en-CA": "2006-01-02", "es": "02/01/2006",
+	"et": "02.01.2006", "fi": "02.01.2006", "fr": "02/01/2006",
+	"fr-CA": "2006-01-02", "hr": "02. 01. 2006.", "hu": "2006. 01. 02.",
+	"it": "02/01/2006", "ja": "2006/01/02", "ko": "2006. 01. 02.",
+	"lt": "2006-01-02", "lv": "02.01.2006", "nb": "02.01.2006",
+	"nl": "02-01-2006", "pl": "02.01.2006", "pt": "02/01/2006",
+	"ro": "02.01.2006", "ru": "02.01.2006", "sk": "02. 01. 2006",
+	"sl": "02. 01. 2006", "sr": "02.01.2006.", "sv": "2006-01-02",
+	"tr": "02.01.2006", "uk": "02.01.2006", "zh": "2006/01/02",
+}
+
+// NewLocale returns the conventions of the BCP 47 tag (hu, en, de-AT ...):
+// the decimal and grouping symbols are from CLDR, the date order from the short date pattern.
+func NewLocale(tag string) (Locale, error) {
+	t, err := language.Parse(tag)
+	if err != nil {
+		return Locale{}, err
+	}
+	loc := Locale{Tag: t.String(), Decimal: ".", DateLayout: "2006-01-02"}
+	// Split 12 345 678,5 into the runs of non-digits: the first is the grouping, the last is the decimal separator.
+	seps := strings.FieldsFunc(message.NewPrinter(t).Sprint(number.Decimal(12345678.5)), unicode.IsDigit)
+	if len(seps) >= 2 {
+		loc.Group, loc.Decimal = seps[0], seps[len(seps)-1]
+	}
+	if layout, ok := dateLayouts[loc.Tag]; ok {
+		loc.DateLayout = layout
+	} else {
+		base, _ := t.Base()
+		region, _ := t.Region()
+		if layout, ok = dateLayouts[base.String()+"-"+region.String()]; ok {
+			loc.DateLayout = layout
+		} else if layout, ok = dateLayouts[base.String()]; ok {
+			loc.DateLayout = layout
+		}
+	}
+	return loc, nil
+}
+
+// FormatDouble returns the number with the locale's decimal and grouping symbols.
+func (loc Locale) FormatDouble(d Double) string {
+	if d.Decimal == nil {
+		return ""
+	}
+	s := d.Decimal.Text('f')
+	if loc.Decimal == "" && loc.Group == "" {
+		return s
+	}
+	var sign string
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	intPart, frac, hasFrac := strings.Cut(s, ".")
+	var buf strings.Builder
+	buf.WriteString(sign)
+	for i, r := range intPart {
+		if i != 0 && loc.Group != "" && (len(intPart)-i)%3 == 0 {
+			buf.WriteString(loc.Group)
+		}
+		buf.WriteRune(r)
+	}
+	if hasFrac {
+		if loc.Decimal == "" {
+			buf.WriteByte('.')
+		} else {
+			buf.WriteString(loc.Decimal)
+		}
+		buf.WriteString(frac)
+	}
+	return buf.String()
+}
+
+// FormatDate returns the date in the locale's order.
+func (loc Locale) FormatDate(d Date) string {
+	if loc.DateLayout == "" {
+		return d.String()
+	}
+	return time.Time(d).Format(loc.DateLayout)
+}
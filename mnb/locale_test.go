@@ -0,0 +1,62 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mnb
+
+import (
+	"testing"
+)
+
+func TestNewLocale(t *testing.T) {
+	const nbsp = "\u00a0"
+	for _, tc := range []struct {
+		Tag                       string
+		Decimal, Group, Date      string
+		Number, Negative, Integer string
+	}{
+		{"hu", ",", nbsp, "2024. 05. 03.", "1" + nbsp + "234" + nbsp + "567,89", "-390,5", "12" + nbsp + "345"},
+		{"hu-HU", ",", nbsp, "2024. 05. 03.", "1" + nbsp + "234" + nbsp + "567,89", "-390,5", "12" + nbsp + "345"},
+		{"en", ".", ",", "05/03/2024", "1,234,567.89", "-390.5", "12,345"},
+		{"en-GB", ".", ",", "03/05/2024", "1,234,567.89", "-390.5", "12,345"},
+		{"de", ",", ".", "03.05.2024", "1.234.567,89", "-390,5", "12.345"},
+		{"de-AT", ",", nbsp, "03.05.2024", "1" + nbsp + "234" + nbsp + "567,89", "-390,5", "12" + nbsp + "345"},
+		{"de-CH", ".", "’", "03.05.2024", "1’234’567.89", "-390.5", "12’345"},
+		{"fr-CA", ",", nbsp, "2024-05-03", "1" + nbsp + "234" + nbsp + "567,89", "-390,5", "12" + nbsp + "345"},
+		{"ja", ".", ",", "2024/05/03", "1,234,567.89", "-390.5", "12,345"},
+	} {
+		loc, err := NewLocale(tc.Tag)
+		if err != nil {
+			t.Errorf("%s: %+v", tc.Tag, err)
+			continue
+		}
+		if loc.Decimal != tc.Decimal || loc.Group != tc.Group {
+			t.Errorf("%s: got decimal %q group %q, wanted %q and %q", tc.Tag, loc.Decimal, loc.Group, tc.Decimal, tc.Group)
+		}
+		for _, x := range []struct{ Got, Want string }{
+			{loc.FormatDate(Date(parseTestDay(t, "2024-05-03"))), tc.Date},
+			{loc.FormatDouble(testDouble(t, "1234567.89")), tc.Number},
+			{loc.FormatDouble(testDouble(t, "-390.5")), tc.Negative},
+			{loc.FormatDouble(testDouble(t, "12345")), tc.Integer},
+		} {
+			if x.Got != x.Want {
+				t.Errorf("%s: got %q, wanted %q", tc.Tag, x.Got, x.Want)
+			}
+		}
+	}
+
+	if _, err := NewLocale("not a tag"); err == nil {
+		t.Error("no error for an invalid tag")
+	}
+
+	var zero Locale
+	if got, want := zero.FormatDouble(testDouble(t, "1234567.89")), "1234567.89"; got != want {
+		t.Errorf("zero Locale: got %q, wanted %q", got, want)
+	}
+	if got, want := zero.FormatDate(Date(parseTestDay(t, "2024-05-03"))), "2024-05-03"; got != want {
+		t.Errorf("zero Locale: got %q, wanted %q", got, want)
+	}
+	if got := zero.FormatDouble(Double{}); got != "" {
+		t.Errorf("nil Double: got %q", got)
+	}
+}
@@ -56,6 +56,8 @@ type MNB struct {
 	URL string
 	*slog.Logger
 	*http.Client
+	// Cache, if not nil, is used to answer the repeated calls.
+	Cache Cache
 }
 type MNBAlapkamatService struct {
 	MNB
@@ -393,12 +395,24 @@ var retryStrategy = retry.Strategy{
 	Factor:      2,
 }
 
+// Call posts the SOAP request envelope (without the XML header) with the action
+// to m.URL (defaultURL if that's empty), and returns the content of the result element.
+func (m MNB) Call(ctx context.Context, defaultURL, action string, body string) ([]byte, error) {
+	return m.call(ctx, defaultURL, action, body)
+}
+
 func (m MNB) call(ctx context.Context, defaultURL, action string, body string) ([]byte, error) {
 	URL := m.URL
 	if URL == "" {
 		URL = defaultURL
 	}
 	reqS := xml.Header + body
+	cacheKey := URL + "\x00" + action + "\x00" + body
+	if m.Cache != nil {
+		if b, ok := m.Cache.Get(cacheKey); ok {
+			return append(make([]byte, 0, len(b)), b...), nil
+		}
+	}
 	client := m.Client
 	if client == nil {
 		client = http.DefaultClient
@@ -454,6 +468,9 @@ func (m MNB) call(ctx context.Context, defaultURL, action string, body string) (
 			return append(make([]byte, 0, len(b)), b...), nil
 		}()
 		if err == nil {
+			if m.Cache != nil {
+				m.Cache.Set(cacheKey, append(make([]byte, 0, len(b)), b...))
+			}
 			return b, nil
 		}
 		if firstErr == nil {
@@ -0,0 +1,64 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mnb
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// parseTestDay parses the day (2006-01-02).
+func parseTestDay(t *testing.T, s string) time.Time {
+	t.Helper()
+	d, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+// testDays returns the days from "day CUR=rate CUR/unit=rate ..." lines.
+func testDays(t *testing.T, lines ...string) []DayRates {
+	t.Helper()
+	days := make([]DayRates, 0, len(lines))
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		d := DayRates{Day: Date(parseTestDay(t, fields[0]))}
+		for _, f := range fields[1:] {
+			k, v, _ := strings.Cut(f, "=")
+			r := Rate{Unit: 1}
+			r.Currency, _, _ = strings.Cut(k, "/")
+			if _, unit, ok := strings.Cut(k, "/"); ok {
+				fmt.Sscan(unit, &r.Unit)
+			}
+			if err := r.Rate.UnmarshalText([]byte(v)); err != nil {
+				t.Fatal(err)
+			}
+			d.Rates = append(d.Rates, r)
+		}
+		days = append(days, d)
+	}
+	return days
+}
+
+// testDouble parses s as a Double.
+func testDouble(t *testing.T, s string) Double {
+	t.Helper()
+	d, err := NewDoubleFromString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+// checkDouble checks that got equals (numerically) want.
+func checkDouble(t *testing.T, name string, got Double, want string) {
+	t.Helper()
+	if got.Decimal == nil || got.Cmp(testDouble(t, want).Decimal) != 0 {
+		t.Errorf("%s: got %v, wanted %s", name, got, want)
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mnb
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// CostMethod is how the outflows are matched to the inflows of a currency.
+type CostMethod string
+
+const (
+	// FIFO matches the outflows to the oldest inflows first.
+	FIFO = CostMethod("fifo")
+	// WeightedAverage values the outflows at the average cost of the balance.
+	WeightedAverage = CostMethod("average")
+)
+
+// ParseCostMethod parses the cost method name (fifo or average).
+func ParseCostMethod(s string) (CostMethod, error) {
+	m := CostMethod(strings.ToLower(strings.TrimSpace(s)))
+	switch m {
+	case FIFO, WeightedAverage:
+		return m, nil
+	}
+	return m, fmt.Errorf("unknown cost method %q (fifo or average is accepted)", s)
+}
+
+// Flow is a foreign currency inflow (positive Amount) or outflow (negative Amount).
+type Flow struct {
+	ID       string
+	Day      Date
+	Currency string
+	Amount   Double
+}
+
+// RealizedFlow is a Flow valued at the rate of its day.
+type RealizedFlow struct {
+	Flow
+	// RateDay is the publication day of Rate: the day of the flow, or the last publication day before it.
+	RateDay Date
+	// Rate is the HUF value of one unit of the currency.
+	Rate Double
+	// Value is the HUF value of the flow at Rate (negative for outflows).
+	Value Double
+	// Cost is the book value of the outflow, GainLoss is the realized gain (or loss, if negative):
+	// -Value - Cost. Both are zero for inflows.
+	Cost, GainLoss Double
+	// Balance is the currency balance after the flow, BookValue is its HUF book value.
+	Balance, BookValue Double
+}
+
+// RealizedTotal is the sum of the realized gains and losses of a currency, and its closing balance.
+type RealizedTotal struct {
+	Currency                     string
+	Count                        int
+	GainLoss, Balance, BookValue Double
+}
+
+// RealizationReport is the realized gains and losses of the flows, with the Method.
+type RealizationReport struct {
+	Method CostMethod
+	Flows  []RealizedFlow
+	Totals []RealizedTotal
+}
+
+// lot is a part of the balance, with its HUF book value.
+type lot struct{ Amount, Value Double }
+
+// Realize values the flows at the rates of their days from the History, in day order,
+// and computes the realized gain or loss of the outflows with the cost method,
+// rounding the HUF values to places decimals.
+func Realize(h History, flows []Flow, method CostMethod, places int32) (RealizationReport, error) {
+	flows = slices.Clone(flows)
+	slices.SortStableFunc(flows, func(a, b Flow) int { return time.Time(a.Day).Compare(time.Time(b.Day)) })
+	report := RealizationReport{Method: method, Flows: make([]RealizedFlow, 0, len(flows))}
+	lots := make(map[string][]lot)
+	totals := make(map[string]*RealizedTotal)
+	zero := NewDouble(0, 0)
+	for _, f := range flows {
+		rf := RealizedFlow{Flow: f, Cost: zero, GainLoss: zero}
+		r, rateDay, err := h.RateAt(time.Time(f.Day), f.Currency)
+		if err != nil {
+			return report, fmt.Errorf("%s: %w", f.ID, err)
+		}
+		if rf.Rate, err = r.PerUnit(); err != nil {
+			return report, fmt.Errorf("%s: %w", f.ID, err)
+		}
+		rf.RateDay = rateDay
+		value, err := f.Amount.Mul(rf.Rate)
+		if err != nil {
+			return report, fmt.Errorf("%s: %w", f.ID, err)
+		}
+		rf.Value = value.Round(places)
+
+		t := totals[f.Currency]
+		if t == nil {
+			t = &RealizedTotal{Currency: f.Currency, GainLoss: zero, Balance: zero, BookValue: zero}
+			totals[f.Currency] = t
+		}
+		t.Count++
+		ls := lots[f.Currency]
+		if f.Amount.Sign() >= 0 {
+			if method == WeightedAverage && len(ls) != 0 {
+				if ls[0], err = ls[0].add(lot{Amount: f.Amount, Value: rf.Value}); err != nil {
+					return report, err
+				}
+			} else {
+				ls = append(ls, lot{Amount: f.Amount, Value: rf.Value})
+			}
+		} else {
+			if ls, rf.Cost, err = consume(ls, f.Amount.Neg(), places); err != nil {
+				return report, fmt.Errorf("%s %s %s: %w", f.ID, f.Day, f.Currency, err)
+			}
+			if rf.GainLoss, err = rf.Value.Neg().Sub(rf.Cost); err != nil {
+				return report, err
+			}
+			if t.GainLoss, err = t.GainLoss.Add(rf.GainLoss); err != nil {
+				return report, err
+			}
+		}
+		lots[f.Currency] = ls
+		if rf.Balance, rf.BookValue, err = sumLots(ls); err != nil {
+			return report, err
+		}
+		t.Balance, t.BookValue = rf.Balance, rf.BookValue
+		report.Flows = append(report.Flows, rf)
+	}
+	for _, t := range totals {
+		report.Totals = append(report.Totals, *t)
+	}
+	slices.SortFunc(report.Totals, func(a, b RealizedTotal) int { return cmp.Compare(a.Currency, b.Currency) })
+	return report, nil
+}
+
+func (l lot) add(m lot) (lot, error) {
+	var err error
+	if l.Amount, err = l.Amount.Add(m.Amount); err != nil {
+		return l, err
+	}
+	l.Value, err = l.Value.Add(m.Value)
+	return l, err
+}
+
+// consume removes the amount from the front of the lots, returning the remaining lots,
+// and the book value of the removed amount.
+func consume(lots []lot, amount Double, places int32) ([]lot, Double, error) {
+	cost := NewDouble(0, 0)
+	remaining := amount
+	for remaining.Sign() > 0 {
+		if len(lots) == 0 {
+			return lots, cost, fmt.Errorf("outflow of %s exceeds the balance by %s", amount.String(), remaining.String())
+		}
+		l := &lots[0]
+		take, value := remaining, l.Value
+		if l.Amount.Cmp(remaining.Decimal) > 0 {
+			// a part of the lot: proportional book value
+			v, err := l.Value.Mul(remaining)
+			if err == nil {
+				v, err = v.Quo(l.Amount)
+			}
+			if err != nil {
+				return lots, cost, err
+			}
+			value = v.Round(places)
+		} else {
+			take = l.Amount
+		}
+		var err error
+		if l.Amount, err = l.Amount.Sub(take); err != nil {
+			return lots, cost, err
+		}
+		if l.Value, err = l.Value.Sub(value); err != nil {
+			return lots, cost, err
+		}
+		if remaining, err = remaining.Sub(take); err != nil {
+			return lots, cost, err
+		}
+		if cost, err = cost.Add(value); err != nil {
+			return lots, cost, err
+		}
+		if l.Amount.Sign() == 0 {
+			lots = lots[1:]
+		}
+	}
+	return lots, cost, nil
+}
+
+func sumLots(lots []lot) (amount, value Double, err error) {
+	sum := lot{Amount: NewDouble(0, 0), Value: NewDouble(0, 0)}
+	for _, l := range lots {
+		if sum, err = sum.add(l); err != nil {
+			return sum.Amount, sum.Value, err
+		}
+	}
+	return sum.Amount, sum.Value, nil
+}
+
+// Realize values the flows at the rates of their days (or the last publication day before them),
+// fetching the rates with one GetExchangeRates call, and computes the realized gains and losses.
+func (m MNBArfolyamService) Realize(ctx context.Context, flows []Flow, method CostMethod, places int32) (RealizationReport, error) {
+	if len(flows) == 0 {
+		return RealizationReport{Method: method}, nil
+	}
+	begin, end := time.Time(flows[0].Day), time.Time(flows[0].Day)
+	var currencies []string
+	for _, f := range flows {
+		if !slices.Contains(currencies, f.Currency) {
+			currencies = append(currencies, f.Currency)
+		}
+		if t := time.Time(f.Day); t.Before(begin) {
+			begin = t
+		} else if t.After(end) {
+			end = t
+		}
+	}
+	h, err := m.GetHistory(ctx, begin, end, currencies...)
+	if err != nil {
+		return RealizationReport{}, err
+	}
+	return Realize(h, flows, method, places)
+}
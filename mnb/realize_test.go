@@ -0,0 +1,134 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mnb
+
+import (
+	"testing"
+)
+
+func TestRealize(t *testing.T) {
+	h := NewHistory(testDays(t,
+		"2024-05-02 EUR=390 JPY/100=250",
+		"2024-05-03 EUR=400 JPY/100=252",
+		"2024-05-06 EUR=410 JPY/100=251",
+		"2024-05-07 EUR=380 JPY/100=240",
+	))
+	testFlow := func(id, day, currency, amount string) Flow {
+		return Flow{ID: id, Day: Date(parseTestDay(t, day)), Currency: currency, Amount: testDouble(t, amount)}
+	}
+	flows := []Flow{
+		// out of order: Realize sorts them by day
+		testFlow("c", "2024-05-06", "EUR", "-150"),
+		testFlow("a", "2024-05-02", "EUR", "100"),
+		testFlow("b", "2024-05-03", "EUR", "100"),
+		testFlow("j", "2024-05-04", "JPY", "1000"), // Saturday
+		testFlow("d", "2024-05-07", "EUR", "-50"),
+	}
+	type flowWant struct{ ID, Value, Cost, GainLoss, Balance, BookValue string }
+	for _, tc := range []struct {
+		Method   CostMethod
+		Flows    []flowWant
+		GainLoss string
+	}{
+		{Method: FIFO, GainLoss: "1500", Flows: []flowWant{
+			{"a", "39000", "0", "0", "100", "39000"},
+			{"b", "40000", "0", "0", "200", "79000"},
+			{"j", "2520", "0", "0", "1000", "2520"},
+			// the first lot and half of the second
+			{"c", "-61500", "59000", "2500", "50", "20000"},
+			{"d", "-19000", "20000", "-1000", "0", "0"},
+		}},
+		{Method: WeightedAverage, GainLoss: "1500", Flows: []flowWant{
+			{"a", "39000", "0", "0", "100", "39000"},
+			{"b", "40000", "0", "0", "200", "79000"},
+			{"j", "2520", "0", "0", "1000", "2520"},
+			// 150/200 of 79000
+			{"c", "-61500", "59250", "2250", "50", "19750"},
+			{"d", "-19000", "19750", "-750", "0", "0"},
+		}},
+	} {
+		t.Run(string(tc.Method), func(t *testing.T) {
+			report, err := Realize(h, flows, tc.Method, 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(report.Flows) != len(tc.Flows) {
+				t.Fatalf("got %d flows, wanted %d", len(report.Flows), len(tc.Flows))
+			}
+			for i, want := range tc.Flows {
+				f := report.Flows[i]
+				if f.ID != want.ID {
+					t.Errorf("%d. got %s, wanted %s", i, f.ID, want.ID)
+					continue
+				}
+				checkDouble(t, f.ID+" value", f.Value, want.Value)
+				checkDouble(t, f.ID+" cost", f.Cost, want.Cost)
+				checkDouble(t, f.ID+" gain/loss", f.GainLoss, want.GainLoss)
+				checkDouble(t, f.ID+" balance", f.Balance, want.Balance)
+				checkDouble(t, f.ID+" book value", f.BookValue, want.BookValue)
+			}
+			if got := report.Flows[2].RateDay.String(); got != "2024-05-03" {
+				t.Errorf("got the rate of %s for the Saturday flow, wanted 2024-05-03", got)
+			}
+			if len(report.Totals) != 2 || report.Totals[0].Currency != "EUR" || report.Totals[1].Currency != "JPY" {
+				t.Fatalf("got totals %+v", report.Totals)
+			}
+			eur := report.Totals[0]
+			if eur.Count != 4 {
+				t.Errorf("got EUR count %d, wanted 4", eur.Count)
+			}
+			checkDouble(t, "EUR gain/loss", eur.GainLoss, tc.GainLoss)
+			checkDouble(t, "EUR balance", eur.Balance, "0")
+			checkDouble(t, "JPY book value", report.Totals[1].BookValue, "2520")
+		})
+	}
+}
+
+func TestRealizePartialLot(t *testing.T) {
+	h := NewHistory(testDays(t, "2024-05-02 EUR=390.12", "2024-05-03 EUR=391.37"))
+	flows := []Flow{
+		{ID: "in", Day: Date(parseTestDay(t, "2024-05-02")), Currency: "EUR", Amount: testDouble(t, "3")},
+		{ID: "out", Day: Date(parseTestDay(t, "2024-05-03")), Currency: "EUR", Amount: testDouble(t, "-1")},
+	}
+	for _, method := range []CostMethod{FIFO, WeightedAverage} {
+		report, err := Realize(h, flows, method, 2)
+		if err != nil {
+			t.Fatalf("%s: %+v", method, err)
+		}
+		out := report.Flows[1]
+		// a third of 1170.36, rounded
+		checkDouble(t, string(method)+" cost", out.Cost, "390.12")
+		checkDouble(t, string(method)+" gain/loss", out.GainLoss, "1.25")
+		checkDouble(t, string(method)+" balance", out.Balance, "2")
+		checkDouble(t, string(method)+" book value", out.BookValue, "780.24")
+	}
+}
+
+func TestRealizeBeyondBalance(t *testing.T) {
+	h := NewHistory(testDays(t, "2024-05-02 EUR=390", "2024-05-03 EUR=400"))
+	for _, method := range []CostMethod{FIFO, WeightedAverage} {
+		for _, tc := range []struct {
+			Name  string
+			Flows []Flow
+			Want  string
+		}{
+			{Name: "sell more", Flows: []Flow{
+				{ID: "in", Day: Date(parseTestDay(t, "2024-05-02")), Currency: "EUR", Amount: testDouble(t, "100")},
+				{ID: "out", Day: Date(parseTestDay(t, "2024-05-03")), Currency: "EUR", Amount: testDouble(t, "-150")},
+			}, Want: "out 2024-05-03 EUR: outflow of 150 exceeds the balance by 50"},
+			{Name: "sell before buy", Flows: []Flow{
+				{ID: "in", Day: Date(parseTestDay(t, "2024-05-03")), Currency: "EUR", Amount: testDouble(t, "100")},
+				{ID: "out", Day: Date(parseTestDay(t, "2024-05-02")), Currency: "EUR", Amount: testDouble(t, "-1")},
+			}, Want: "out 2024-05-02 EUR: outflow of 1 exceeds the balance by 1"},
+		} {
+			_, err := Realize(h, tc.Flows, method, 2)
+			if err == nil {
+				t.Errorf("%s %s: no error", method, tc.Name)
+			} else if err.Error() != tc.Want {
+				t.Errorf("%s %s: got %q, wanted %q", method, tc.Name, err.Error(), tc.Want)
+			}
+		}
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mnb
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// OpenItem is an open foreign currency item: a receivable, a payable or an account balance.
+type OpenItem struct {
+	ID       string
+	Currency string
+	Amount   Double
+	// Booked is the HUF value the item is on the books with.
+	// If it's missing, it's the Amount at the rate of BookingDay.
+	Booked     Double
+	BookingDay Date
+}
+
+// Revaluation is an OpenItem revalued at the rate of the revaluation day.
+type Revaluation struct {
+	OpenItem
+	// RateDay is the publication day of Rate: the revaluation day, or the last publication day before it.
+	RateDay Date
+	// Rate is the HUF value of one unit of the currency.
+	Rate Double
+	// Value is the revalued HUF amount, GainLoss is the unrealized gain (or loss, if negative): Value - Booked.
+	Value, GainLoss Double
+}
+
+// RevaluationTotal is the sum of the revaluations of a currency.
+type RevaluationTotal struct {
+	Currency                        string
+	Count                           int
+	Amount, Booked, Value, GainLoss Double
+}
+
+// RevaluationReport is the revaluation of the open items on Day.
+type RevaluationReport struct {
+	Day    Date
+	Items  []Revaluation
+	Totals []RevaluationTotal
+}
+
+// Revalue revalues the items at the rates of the day from the History,
+// rounding the HUF values to places decimals.
+// The History must contain the rates of the booking days of the items without Booked value.
+func Revalue(h History, items []OpenItem, day time.Time, places int32) (RevaluationReport, error) {
+	report := RevaluationReport{Day: Date(dayOf(day)), Items: make([]Revaluation, 0, len(items))}
+	totals := make(map[string]*RevaluationTotal)
+	for _, item := range items {
+		rv := Revaluation{OpenItem: item}
+		if rv.Booked.Decimal == nil {
+			if time.Time(item.BookingDay).IsZero() {
+				return report, fmt.Errorf("%s: neither booked value nor booking day", item.ID)
+			}
+			booked, err := h.value(item.Amount, item.Currency, time.Time(item.BookingDay))
+			if err != nil {
+				return report, fmt.Errorf("%s: booking: %w", item.ID, err)
+			}
+			rv.Booked = booked.Round(places)
+		}
+		r, rateDay, err := h.RateAt(day, item.Currency)
+		if err != nil {
+			return report, fmt.Errorf("%s: %w", item.ID, err)
+		}
+		if rv.Rate, err = r.PerUnit(); err != nil {
+			return report, fmt.Errorf("%s: %w", item.ID, err)
+		}
+		rv.RateDay = rateDay
+		value, err := item.Amount.Mul(rv.Rate)
+		if err != nil {
+			return report, fmt.Errorf("%s: %w", item.ID, err)
+		}
+		rv.Value = value.Round(places)
+		if rv.GainLoss, err = rv.Value.Sub(rv.Booked); err != nil {
+			return report, fmt.Errorf("%s: %w", item.ID, err)
+		}
+		report.Items = append(report.Items, rv)
+
+		t := totals[item.Currency]
+		if t == nil {
+			t = &RevaluationTotal{Currency: item.Currency}
+			totals[item.Currency] = t
+		}
+		t.Count++
+		for _, x := range []struct {
+			sum *Double
+			v   Double
+		}{
+			{&t.Amount, rv.Amount}, {&t.Booked, rv.Booked}, {&t.Value, rv.Value}, {&t.GainLoss, rv.GainLoss},
+		} {
+			if *x.sum, err = x.sum.Add(x.v); err != nil {
+				return report, err
+			}
+		}
+	}
+	for _, t := range totals {
+		report.Totals = append(report.Totals, *t)
+	}
+	slices.SortFunc(report.Totals, func(a, b RevaluationTotal) int { return cmp.Compare(a.Currency, b.Currency) })
+	return report, nil
+}
+
+// value returns the HUF value of the amount of the currency, at the rate in effect on the day.
+func (h History) value(amount Double, currency string, day time.Time) (Double, error) {
+	r, _, err := h.RateAt(day, currency)
+	if err != nil {
+		return Double{}, err
+	}
+	p, err := r.PerUnit()
+	if err != nil {
+		return Double{}, err
+	}
+	return amount.Mul(p)
+}
+
+// Revalue revalues the items at the rates of the day (or the last publication day before it),
+// fetching the rates of the revaluation and the booking days with one GetExchangeRates call.
+func (m MNBArfolyamService) Revalue(ctx context.Context, items []OpenItem, day time.Time, places int32) (RevaluationReport, error) {
+	begin := dayOf(day)
+	var currencies []string
+	for _, item := range items {
+		if !slices.Contains(currencies, item.Currency) {
+			currencies = append(currencies, item.Currency)
+		}
+		if t := time.Time(item.BookingDay); item.Booked.Decimal == nil && !t.IsZero() && t.Before(begin) {
+			begin = t
+		}
+	}
+	h, err := m.GetHistory(ctx, begin, day, currencies...)
+	if err != nil {
+		return RevaluationReport{}, err
+	}
+	return Revalue(h, items, day, places)
+}
@@ -0,0 +1,138 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mnb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestHistoryRateAt(t *testing.T) {
+	h := NewHistory(testDays(t,
+		"2024-05-03 EUR=390.5",
+		"2024-04-30 EUR=392 JPY/100=240",
+	))
+	for _, tc := range []struct {
+		Day, Currency string
+		// Want is "unit rate publication-day", empty for ErrNoRate.
+		Want string
+	}{
+		{"2024-04-30", "EUR", "1 392 2024-04-30"},
+		{"2024-05-01", "EUR", "1 392 2024-04-30"},
+		{"2024-05-05", "EUR", "1 390.5 2024-05-03"},
+		{"2024-05-02", "JPY", "100 240 2024-04-30"},
+		{"2024-05-03", "JPY", ""}, // not in the last publication
+		{"2024-04-29", "EUR", ""},
+		{"2024-04-29", "HUF", "1 1 2024-04-29"},
+	} {
+		r, day, err := h.RateAt(parseTestDay(t, tc.Day), tc.Currency)
+		if tc.Want == "" {
+			if !errors.Is(err, ErrNoRate) {
+				t.Errorf("%s %s: got %+v, wanted %v", tc.Day, tc.Currency, err, ErrNoRate)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s %s: %+v", tc.Day, tc.Currency, err)
+			continue
+		}
+		if got := fmt.Sprintf("%d %s %s", r.Unit, r.Rate.String(), day); got != tc.Want {
+			t.Errorf("%s %s: got %s, wanted %s", tc.Day, tc.Currency, got, tc.Want)
+		}
+	}
+}
+
+func TestRevalue(t *testing.T) {
+	h := NewHistory(testDays(t,
+		"2024-04-30 EUR=392 JPY/100=240",
+		"2024-05-03 EUR=390.5 JPY/100=236.5",
+	))
+	items := []OpenItem{
+		{ID: "a", Currency: "EUR", Amount: testDouble(t, "100"), Booked: testDouble(t, "39000")},
+		// booked on a holiday, at the rate of the day before
+		{ID: "b", Currency: "EUR", Amount: testDouble(t, "-50"), BookingDay: Date(parseTestDay(t, "2024-05-01"))},
+		{ID: "c", Currency: "JPY", Amount: testDouble(t, "10000"), BookingDay: Date(parseTestDay(t, "2024-04-30"))},
+		{ID: "d", Currency: "HUF", Amount: testDouble(t, "1000"), Booked: testDouble(t, "1000")},
+	}
+	// Sunday: the rates of Friday
+	report, err := Revalue(h, items, parseTestDay(t, "2024-05-05"), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := report.Day.String(); got != "2024-05-05" {
+		t.Errorf("got day %s", got)
+	}
+	for i, want := range []struct{ RateDay, Rate, Booked, Value, GainLoss string }{
+		{"2024-05-03", "390.5", "39000", "39050", "50"},
+		{"2024-05-03", "390.5", "-19600", "-19525", "75"},
+		{"2024-05-03", "2.365", "24000", "23650", "-350"},
+		{"2024-05-05", "1", "1000", "1000", "0"},
+	} {
+		rv := report.Items[i]
+		if got := rv.RateDay.String(); got != want.RateDay {
+			t.Errorf("%s: got the rate of %s, wanted %s", rv.ID, got, want.RateDay)
+		}
+		checkDouble(t, rv.ID+" rate", rv.Rate, want.Rate)
+		checkDouble(t, rv.ID+" booked", rv.Booked, want.Booked)
+		checkDouble(t, rv.ID+" value", rv.Value, want.Value)
+		checkDouble(t, rv.ID+" gain/loss", rv.GainLoss, want.GainLoss)
+	}
+	var totals []string
+	for _, x := range report.Totals {
+		totals = append(totals, fmt.Sprintf("%s %d %s %s %s %s", x.Currency, x.Count,
+			x.Amount.String(), x.Booked.String(), x.Value.String(), x.GainLoss.String()))
+	}
+	if got, want := strings.Join(totals, "\n"), strings.Join([]string{
+		"EUR 2 50 19400 19525 125",
+		"HUF 1 1000 1000 1000 0",
+		"JPY 1 10000 24000 23650 -350",
+	}, "\n"); got != want {
+		t.Errorf("got totals\n%s\nwanted\n%s", got, want)
+	}
+}
+
+func TestRevalueErrors(t *testing.T) {
+	h := NewHistory(testDays(t, "2024-04-30 EUR=392"))
+	day := parseTestDay(t, "2024-05-03")
+	for _, tc := range []struct {
+		Item OpenItem
+		Want string
+	}{
+		{OpenItem{ID: "x", Currency: "EUR", Amount: testDouble(t, "1")}, "x: neither booked value nor booking day"},
+		{OpenItem{ID: "x", Currency: "EUR", Amount: testDouble(t, "1"), BookingDay: Date(parseTestDay(t, "2024-04-29"))},
+			"x: booking: EUR on 2024-04-29"},
+		{OpenItem{ID: "x", Currency: "GBP", Amount: testDouble(t, "1"), Booked: testDouble(t, "480")}, "x: GBP on 2024-05-03"},
+	} {
+		_, err := Revalue(h, []OpenItem{tc.Item}, day, 2)
+		if err == nil {
+			t.Errorf("%+v: no error", tc.Item)
+		} else if !strings.HasPrefix(err.Error(), tc.Want) {
+			t.Errorf("got %q, wanted %q", err.Error(), tc.Want)
+		}
+	}
+}
+
+func TestMNBRevalue(t *testing.T) {
+	m := NewMNBArfolyamService(serveFakeMNB(t, testDays(t,
+		"2024-02-29 EUR=394",
+		"2024-04-30 EUR=392",
+		"2024-05-03 EUR=390.5",
+	), nil).URL, nil, nil)
+	// booked months earlier, at the rate of the publication before the booking day,
+	// fetched in the same call as the revaluation day's
+	report, err := m.Revalue(context.Background(), []OpenItem{
+		{ID: "a", Currency: "EUR", Amount: testDouble(t, "10"), BookingDay: Date(parseTestDay(t, "2024-03-01"))},
+	}, parseTestDay(t, "2024-05-04"), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rv := report.Items[0]
+	checkDouble(t, "booked", rv.Booked, "3940")
+	checkDouble(t, "value", rv.Value, "3905")
+	checkDouble(t, "gain/loss", rv.GainLoss, "-35")
+}
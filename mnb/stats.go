@@ -0,0 +1,138 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mnb
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/cockroachdb/apd/v3"
+)
+
+// DecimalContext is used for the decimal arithmetic, here and by the users of the package.
+var DecimalContext = apd.BaseContext.WithPrecision(34)
+
+// Observation is a rate on a day.
+type Observation struct {
+	Day  Date
+	Rate Double
+}
+
+// Series is the rates of one currency, in ascending day order.
+type Series struct {
+	Currency     string
+	Unit         int
+	Observations []Observation
+}
+
+// SplitSeries splits the days into per-currency series, ordered by currency.
+func SplitSeries(days []DayRates) []Series {
+	idx := make(map[string]int)
+	var series []Series
+	for _, day := range days {
+		for _, rate := range day.Rates {
+			i, ok := idx[rate.Currency]
+			if !ok {
+				i = len(series)
+				idx[rate.Currency] = i
+				series = append(series, Series{Currency: rate.Currency})
+			}
+			series[i].Observations = append(series[i].Observations, Observation{Day: day.Day, Rate: rate.Rate})
+			series[i].Unit = rate.Unit
+		}
+	}
+	for i := range series {
+		series[i].sort()
+	}
+	slices.SortFunc(series, func(a, b Series) int { return cmp.Compare(a.Currency, b.Currency) })
+	return series
+}
+
+// BaseRateSeries returns the base rates as a Series.
+func BaseRateSeries(rates []MNBBaseRate) Series {
+	s := Series{Observations: make([]Observation, 0, len(rates))}
+	for _, r := range rates {
+		s.Observations = append(s.Observations, Observation{Day: r.Publication, Rate: r.Rate})
+	}
+	s.sort()
+	return s
+}
+
+func (s *Series) sort() {
+	// MNB returns the newest day first
+	slices.SortStableFunc(s.Observations, func(a, b Observation) int {
+		return time.Time(a.Day).Compare(time.Time(b.Day))
+	})
+}
+
+// Summary holds the statistics of a Series.
+type Summary struct {
+	Currency              string
+	Unit                  int
+	Count                 int
+	First, Last, Min, Max Observation
+	Mean                  Double
+	// Change is the percent change from First to Last.
+	Change Double
+}
+
+// Summarize returns the first, last, min, max, mean and the percent change of the series.
+// The observations without rate are skipped, and not counted.
+func (s Series) Summarize() (Summary, error) {
+	sum := Summary{Currency: s.Currency, Unit: s.Unit}
+	var total apd.Decimal
+	for _, o := range s.Observations {
+		if o.Rate.Decimal == nil {
+			continue
+		}
+		if sum.Count == 0 {
+			sum.First, sum.Min, sum.Max = o, o, o
+		}
+		sum.Count++
+		sum.Last = o
+		if o.Rate.Cmp(sum.Min.Rate.Decimal) < 0 {
+			sum.Min = o
+		}
+		if o.Rate.Cmp(sum.Max.Rate.Decimal) > 0 {
+			sum.Max = o
+		}
+		if _, err := DecimalContext.Add(&total, &total, o.Rate.Decimal); err != nil {
+			return sum, err
+		}
+	}
+	if sum.Count == 0 {
+		return sum, nil
+	}
+	var mean apd.Decimal
+	if _, err := DecimalContext.Quo(&mean, &total, apd.New(int64(sum.Count), 0)); err != nil {
+		return sum, err
+	}
+	mean.Reduce(&mean)
+	sum.Mean = Double{Decimal: &mean}
+
+	change, err := PercentChange(sum.First.Rate, sum.Last.Rate)
+	sum.Change = change
+	return sum, err
+}
+
+// PercentChange returns (to - from) / from * 100.
+func PercentChange(from, to Double) (Double, error) {
+	var d apd.Decimal
+	if from.Decimal == nil || to.Decimal == nil || from.IsZero() {
+		return Double{Decimal: &d}, nil
+	}
+	if _, err := DecimalContext.Sub(&d, to.Decimal, from.Decimal); err != nil {
+		return Double{}, err
+	}
+	if _, err := DecimalContext.Quo(&d, &d, from.Decimal); err != nil {
+		return Double{}, err
+	}
+	if _, err := DecimalContext.Mul(&d, &d, apd.New(100, 0)); err != nil {
+		return Double{}, err
+	}
+	d.Reduce(&d)
+	return Double{Decimal: &d}, nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mnb
+
+import (
+	"testing"
+)
+
+// testSeries returns a series of day=rate pairs; an empty rate is a missing one.
+func testSeries(t *testing.T, pairs ...string) Series {
+	t.Helper()
+	s := Series{Currency: "EUR", Unit: 1}
+	for i := 0; i < len(pairs); i += 2 {
+		o := Observation{Day: Date(parseTestDay(t, pairs[i]))}
+		if pairs[i+1] != "" {
+			if err := o.Rate.UnmarshalText([]byte(pairs[i+1])); err != nil {
+				t.Fatal(err)
+			}
+		}
+		s.Observations = append(s.Observations, o)
+	}
+	return s
+}
+
+func TestSeriesSummarize(t *testing.T) {
+	for _, tc := range []struct {
+		Name                  string
+		Series                Series
+		Count                 int
+		First, Last, Min, Max string
+		Mean, Change          string
+	}{
+		{Name: "full",
+			Series: testSeries(t, "2024-05-02", "392", "2024-05-03", "390", "2024-05-06", "394"),
+			Count:  3, First: "2024-05-02", Last: "2024-05-06", Min: "2024-05-03", Max: "2024-05-06",
+			Mean: "392", Change: "0.5102040816326530612244897959183673"},
+		{Name: "missing first",
+			Series: testSeries(t, "2024-05-02", "", "2024-05-03", "390", "2024-05-06", "394"),
+			Count:  2, First: "2024-05-03", Last: "2024-05-06", Min: "2024-05-03", Max: "2024-05-06",
+			Mean: "392", Change: "1.025641025641025641025641025641026"},
+		{Name: "missing last",
+			Series: testSeries(t, "2024-05-02", "392", "2024-05-03", "390", "2024-05-06", ""),
+			Count:  2, First: "2024-05-02", Last: "2024-05-03", Min: "2024-05-03", Max: "2024-05-02",
+			Mean: "391", Change: "-0.5102040816326530612244897959183673"},
+		{Name: "all missing", Series: testSeries(t, "2024-05-02", "", "2024-05-03", "")},
+		{Name: "empty", Series: testSeries(t)},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			sum, err := tc.Series.Summarize()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sum.Count != tc.Count {
+				t.Errorf("got count %d, wanted %d", sum.Count, tc.Count)
+			}
+			if tc.Count == 0 {
+				return
+			}
+			for _, x := range []struct {
+				Name      string
+				Got, Want string
+			}{
+				{"first", sum.First.Day.String(), tc.First},
+				{"last", sum.Last.Day.String(), tc.Last},
+				{"min", sum.Min.Day.String(), tc.Min},
+				{"max", sum.Max.Day.String(), tc.Max},
+				{"mean", sum.Mean.String(), tc.Mean},
+				{"change", sum.Change.String(), tc.Change},
+			} {
+				if x.Got != x.Want {
+					t.Errorf("%s: got %s, wanted %s", x.Name, x.Got, x.Want)
+				}
+			}
+		})
+	}
+}
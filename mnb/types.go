@@ -82,3 +82,66 @@ func (d *Double) UnmarshalText(data []byte) error {
 	}
 	return nil
 }
+
+// UnmarshalJSON accepts both JSON numbers and strings.
+func (d *Double) UnmarshalJSON(data []byte) error {
+	return d.UnmarshalText(bytes.Trim(data, `"`))
+}
+
+// Round returns the number rounded to at most the given decimal places.
+func (d Double) Round(places int32) Double {
+	if d.Decimal == nil {
+		return d
+	}
+	var z apd.Decimal
+	if _, err := DecimalContext.Quantize(&z, d.Decimal, -places); err != nil {
+		return d
+	}
+	z.Reduce(&z)
+	return Double{Decimal: &z}
+}
+
+// Float64 returns the nearest float64 value of the number.
+func (d Double) Float64() float64 {
+	if d.Decimal == nil {
+		return 0
+	}
+	f, _ := d.Decimal.Float64()
+	return f
+}
+
+// Add returns d + e; the missing numbers are zeros.
+func (d Double) Add(e Double) (Double, error) { return d.arith(DecimalContext.Add, e) }
+
+// Sub returns d - e; the missing numbers are zeros.
+func (d Double) Sub(e Double) (Double, error) { return d.arith(DecimalContext.Sub, e) }
+
+// Mul returns d * e; the missing numbers are zeros.
+func (d Double) Mul(e Double) (Double, error) { return d.arith(DecimalContext.Mul, e) }
+
+// Quo returns d / e; the missing numbers are zeros.
+func (d Double) Quo(e Double) (Double, error) { return d.arith(DecimalContext.Quo, e) }
+
+// Neg returns -d; the missing number is zero.
+func (d Double) Neg() Double {
+	var z apd.Decimal
+	if d.Decimal != nil {
+		z.Neg(d.Decimal)
+	}
+	return Double{Decimal: &z}
+}
+
+func (d Double) arith(op func(z, x, y *apd.Decimal) (apd.Condition, error), e Double) (Double, error) {
+	var x, y, z apd.Decimal
+	if d.Decimal != nil {
+		x.Set(d.Decimal)
+	}
+	if e.Decimal != nil {
+		y.Set(e.Decimal)
+	}
+	if _, err := op(&z, &x, &y); err != nil {
+		return Double{}, err
+	}
+	z.Reduce(&z)
+	return Double{Decimal: &z}, nil
+}
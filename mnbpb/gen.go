@@ -0,0 +1,8 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package mnbpb is the protobuf/gRPC API of mnbarf.
+package mnbpb
+
+//go:generate buf generate --template buf.gen.yaml mnb.proto
@@ -0,0 +1,698 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: mnb.proto
+
+package mnbpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetRatesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// first day, 22 business days before yesterday by default
+	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	// last day, yesterday by default
+	To            string   `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Currencies    []string `protobuf:"bytes,3,rep,name=currencies,proto3" json:"currencies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRatesRequest) Reset() {
+	*x = GetRatesRequest{}
+	mi := &file_mnb_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRatesRequest) ProtoMessage() {}
+
+func (x *GetRatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mnb_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRatesRequest.ProtoReflect.Descriptor instead.
+func (*GetRatesRequest) Descriptor() ([]byte, []int) {
+	return file_mnb_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetRatesRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *GetRatesRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *GetRatesRequest) GetCurrencies() []string {
+	if x != nil {
+		return x.Currencies
+	}
+	return nil
+}
+
+type Rate struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Currency string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	// the rate is for this many units of the currency
+	Unit          int32  `protobuf:"varint,2,opt,name=unit,proto3" json:"unit,omitempty"`
+	Rate          string `protobuf:"bytes,3,opt,name=rate,proto3" json:"rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Rate) Reset() {
+	*x = Rate{}
+	mi := &file_mnb_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rate) ProtoMessage() {}
+
+func (x *Rate) ProtoReflect() protoreflect.Message {
+	mi := &file_mnb_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rate.ProtoReflect.Descriptor instead.
+func (*Rate) Descriptor() ([]byte, []int) {
+	return file_mnb_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Rate) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Rate) GetUnit() int32 {
+	if x != nil {
+		return x.Unit
+	}
+	return 0
+}
+
+func (x *Rate) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
+}
+
+type DayRates struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Day           string                 `protobuf:"bytes,1,opt,name=day,proto3" json:"day,omitempty"`
+	Rates         []*Rate                `protobuf:"bytes,2,rep,name=rates,proto3" json:"rates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DayRates) Reset() {
+	*x = DayRates{}
+	mi := &file_mnb_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DayRates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DayRates) ProtoMessage() {}
+
+func (x *DayRates) ProtoReflect() protoreflect.Message {
+	mi := &file_mnb_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DayRates.ProtoReflect.Descriptor instead.
+func (*DayRates) Descriptor() ([]byte, []int) {
+	return file_mnb_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DayRates) GetDay() string {
+	if x != nil {
+		return x.Day
+	}
+	return ""
+}
+
+func (x *DayRates) GetRates() []*Rate {
+	if x != nil {
+		return x.Rates
+	}
+	return nil
+}
+
+type GetRatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Days          []*DayRates            `protobuf:"bytes,1,rep,name=days,proto3" json:"days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRatesResponse) Reset() {
+	*x = GetRatesResponse{}
+	mi := &file_mnb_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRatesResponse) ProtoMessage() {}
+
+func (x *GetRatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mnb_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRatesResponse.ProtoReflect.Descriptor instead.
+func (*GetRatesResponse) Descriptor() ([]byte, []int) {
+	return file_mnb_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetRatesResponse) GetDays() []*DayRates {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+type ConvertRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 1 by default
+	Amount string `protobuf:"bytes,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	From   string `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	// HUF by default
+	To string `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	// the rates of this day (or the last publication day before it); the current rates by default
+	Date          string `protobuf:"bytes,4,opt,name=date,proto3" json:"date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConvertRequest) Reset() {
+	*x = ConvertRequest{}
+	mi := &file_mnb_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConvertRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvertRequest) ProtoMessage() {}
+
+func (x *ConvertRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mnb_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvertRequest.ProtoReflect.Descriptor instead.
+func (*ConvertRequest) Descriptor() ([]byte, []int) {
+	return file_mnb_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ConvertRequest) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *ConvertRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ConvertRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ConvertRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+type ConvertResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Amount string                 `protobuf:"bytes,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	From   string                 `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To     string                 `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	// the day of the rates used
+	Day           string `protobuf:"bytes,4,opt,name=day,proto3" json:"day,omitempty"`
+	Rate          string `protobuf:"bytes,5,opt,name=rate,proto3" json:"rate,omitempty"`
+	Result        string `protobuf:"bytes,6,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConvertResponse) Reset() {
+	*x = ConvertResponse{}
+	mi := &file_mnb_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConvertResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvertResponse) ProtoMessage() {}
+
+func (x *ConvertResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mnb_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvertResponse.ProtoReflect.Descriptor instead.
+func (*ConvertResponse) Descriptor() ([]byte, []int) {
+	return file_mnb_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ConvertResponse) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *ConvertResponse) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ConvertResponse) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ConvertResponse) GetDay() string {
+	if x != nil {
+		return x.Day
+	}
+	return ""
+}
+
+func (x *ConvertResponse) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
+}
+
+func (x *ConvertResponse) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+type GetBaseRateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the current base rate if empty
+	Date          string `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBaseRateRequest) Reset() {
+	*x = GetBaseRateRequest{}
+	mi := &file_mnb_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBaseRateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBaseRateRequest) ProtoMessage() {}
+
+func (x *GetBaseRateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mnb_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBaseRateRequest.ProtoReflect.Descriptor instead.
+func (*GetBaseRateRequest) Descriptor() ([]byte, []int) {
+	return file_mnb_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetBaseRateRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+type BaseRate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Publication   string                 `protobuf:"bytes,1,opt,name=publication,proto3" json:"publication,omitempty"`
+	Rate          string                 `protobuf:"bytes,2,opt,name=rate,proto3" json:"rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BaseRate) Reset() {
+	*x = BaseRate{}
+	mi := &file_mnb_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BaseRate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BaseRate) ProtoMessage() {}
+
+func (x *BaseRate) ProtoReflect() protoreflect.Message {
+	mi := &file_mnb_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BaseRate.ProtoReflect.Descriptor instead.
+func (*BaseRate) Descriptor() ([]byte, []int) {
+	return file_mnb_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BaseRate) GetPublication() string {
+	if x != nil {
+		return x.Publication
+	}
+	return ""
+}
+
+func (x *BaseRate) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
+}
+
+type ListCurrenciesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCurrenciesRequest) Reset() {
+	*x = ListCurrenciesRequest{}
+	mi := &file_mnb_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCurrenciesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCurrenciesRequest) ProtoMessage() {}
+
+func (x *ListCurrenciesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mnb_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCurrenciesRequest.ProtoReflect.Descriptor instead.
+func (*ListCurrenciesRequest) Descriptor() ([]byte, []int) {
+	return file_mnb_proto_rawDescGZIP(), []int{8}
+}
+
+type ListCurrenciesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currencies    []string               `protobuf:"bytes,1,rep,name=currencies,proto3" json:"currencies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCurrenciesResponse) Reset() {
+	*x = ListCurrenciesResponse{}
+	mi := &file_mnb_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCurrenciesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCurrenciesResponse) ProtoMessage() {}
+
+func (x *ListCurrenciesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mnb_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCurrenciesResponse.ProtoReflect.Descriptor instead.
+func (*ListCurrenciesResponse) Descriptor() ([]byte, []int) {
+	return file_mnb_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListCurrenciesResponse) GetCurrencies() []string {
+	if x != nil {
+		return x.Currencies
+	}
+	return nil
+}
+
+var File_mnb_proto protoreflect.FileDescriptor
+
+const file_mnb_proto_rawDesc = "" +
+	"\n" +
+	"\tmnb.proto\x12\tmnbarf.v1\"U\n" +
+	"\x0fGetRatesRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12\x1e\n" +
+	"\n" +
+	"currencies\x18\x03 \x03(\tR\n" +
+	"currencies\"J\n" +
+	"\x04Rate\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12\x12\n" +
+	"\x04unit\x18\x02 \x01(\x05R\x04unit\x12\x12\n" +
+	"\x04rate\x18\x03 \x01(\tR\x04rate\"C\n" +
+	"\bDayRates\x12\x10\n" +
+	"\x03day\x18\x01 \x01(\tR\x03day\x12%\n" +
+	"\x05rates\x18\x02 \x03(\v2\x0f.mnbarf.v1.RateR\x05rates\";\n" +
+	"\x10GetRatesResponse\x12'\n" +
+	"\x04days\x18\x01 \x03(\v2\x13.mnbarf.v1.DayRatesR\x04days\"`\n" +
+	"\x0eConvertRequest\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\tR\x06amount\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\x12\x12\n" +
+	"\x04date\x18\x04 \x01(\tR\x04date\"\x8b\x01\n" +
+	"\x0fConvertResponse\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\tR\x06amount\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\x12\x10\n" +
+	"\x03day\x18\x04 \x01(\tR\x03day\x12\x12\n" +
+	"\x04rate\x18\x05 \x01(\tR\x04rate\x12\x16\n" +
+	"\x06result\x18\x06 \x01(\tR\x06result\"(\n" +
+	"\x12GetBaseRateRequest\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\"@\n" +
+	"\bBaseRate\x12 \n" +
+	"\vpublication\x18\x01 \x01(\tR\vpublication\x12\x12\n" +
+	"\x04rate\x18\x02 \x01(\tR\x04rate\"\x17\n" +
+	"\x15ListCurrenciesRequest\"8\n" +
+	"\x16ListCurrenciesResponse\x12\x1e\n" +
+	"\n" +
+	"currencies\x18\x01 \x03(\tR\n" +
+	"currencies2\xef\x02\n" +
+	"\n" +
+	"MNBService\x12C\n" +
+	"\bGetRates\x12\x1a.mnbarf.v1.GetRatesRequest\x1a\x1b.mnbarf.v1.GetRatesResponse\x12@\n" +
+	"\vStreamRates\x12\x1a.mnbarf.v1.GetRatesRequest\x1a\x13.mnbarf.v1.DayRates0\x01\x12@\n" +
+	"\aConvert\x12\x19.mnbarf.v1.ConvertRequest\x1a\x1a.mnbarf.v1.ConvertResponse\x12A\n" +
+	"\vGetBaseRate\x12\x1d.mnbarf.v1.GetBaseRateRequest\x1a\x13.mnbarf.v1.BaseRate\x12U\n" +
+	"\x0eListCurrencies\x12 .mnbarf.v1.ListCurrenciesRequest\x1a!.mnbarf.v1.ListCurrenciesResponseB6\n" +
+	"\x10hu.mnb.mnbarf.v1P\x01Z github.com/tgulacsi/mnbarf/mnbpbb\x06proto3"
+
+var (
+	file_mnb_proto_rawDescOnce sync.Once
+	file_mnb_proto_rawDescData []byte
+)
+
+func file_mnb_proto_rawDescGZIP() []byte {
+	file_mnb_proto_rawDescOnce.Do(func() {
+		file_mnb_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_mnb_proto_rawDesc), len(file_mnb_proto_rawDesc)))
+	})
+	return file_mnb_proto_rawDescData
+}
+
+var file_mnb_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_mnb_proto_goTypes = []any{
+	(*GetRatesRequest)(nil),        // 0: mnbarf.v1.GetRatesRequest
+	(*Rate)(nil),                   // 1: mnbarf.v1.Rate
+	(*DayRates)(nil),               // 2: mnbarf.v1.DayRates
+	(*GetRatesResponse)(nil),       // 3: mnbarf.v1.GetRatesResponse
+	(*ConvertRequest)(nil),         // 4: mnbarf.v1.ConvertRequest
+	(*ConvertResponse)(nil),        // 5: mnbarf.v1.ConvertResponse
+	(*GetBaseRateRequest)(nil),     // 6: mnbarf.v1.GetBaseRateRequest
+	(*BaseRate)(nil),               // 7: mnbarf.v1.BaseRate
+	(*ListCurrenciesRequest)(nil),  // 8: mnbarf.v1.ListCurrenciesRequest
+	(*ListCurrenciesResponse)(nil), // 9: mnbarf.v1.ListCurrenciesResponse
+}
+var file_mnb_proto_depIdxs = []int32{
+	1, // 0: mnbarf.v1.DayRates.rates:type_name -> mnbarf.v1.Rate
+	2, // 1: mnbarf.v1.GetRatesResponse.days:type_name -> mnbarf.v1.DayRates
+	0, // 2: mnbarf.v1.MNBService.GetRates:input_type -> mnbarf.v1.GetRatesRequest
+	0, // 3: mnbarf.v1.MNBService.StreamRates:input_type -> mnbarf.v1.GetRatesRequest
+	4, // 4: mnbarf.v1.MNBService.Convert:input_type -> mnbarf.v1.ConvertRequest
+	6, // 5: mnbarf.v1.MNBService.GetBaseRate:input_type -> mnbarf.v1.GetBaseRateRequest
+	8, // 6: mnbarf.v1.MNBService.ListCurrencies:input_type -> mnbarf.v1.ListCurrenciesRequest
+	3, // 7: mnbarf.v1.MNBService.GetRates:output_type -> mnbarf.v1.GetRatesResponse
+	2, // 8: mnbarf.v1.MNBService.StreamRates:output_type -> mnbarf.v1.DayRates
+	5, // 9: mnbarf.v1.MNBService.Convert:output_type -> mnbarf.v1.ConvertResponse
+	7, // 10: mnbarf.v1.MNBService.GetBaseRate:output_type -> mnbarf.v1.BaseRate
+	9, // 11: mnbarf.v1.MNBService.ListCurrencies:output_type -> mnbarf.v1.ListCurrenciesResponse
+	7, // [7:12] is the sub-list for method output_type
+	2, // [2:7] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_mnb_proto_init() }
+func file_mnb_proto_init() {
+	if File_mnb_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_mnb_proto_rawDesc), len(file_mnb_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_mnb_proto_goTypes,
+		DependencyIndexes: file_mnb_proto_depIdxs,
+		MessageInfos:      file_mnb_proto_msgTypes,
+	}.Build()
+	File_mnb_proto = out.File
+	file_mnb_proto_goTypes = nil
+	file_mnb_proto_depIdxs = nil
+}
@@ -0,0 +1,301 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: mnb.proto
+
+package mnbpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	MNBService_GetRates_FullMethodName       = "/mnbarf.v1.MNBService/GetRates"
+	MNBService_StreamRates_FullMethodName    = "/mnbarf.v1.MNBService/StreamRates"
+	MNBService_Convert_FullMethodName        = "/mnbarf.v1.MNBService/Convert"
+	MNBService_GetBaseRate_FullMethodName    = "/mnbarf.v1.MNBService/GetBaseRate"
+	MNBService_ListCurrencies_FullMethodName = "/mnbarf.v1.MNBService/ListCurrencies"
+)
+
+// MNBServiceClient is the client API for MNBService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// MNBService serves the exchange rates and the base rate of the Hungarian National Bank.
+//
+// Days are ISO 8601 dates (2006-01-02), decimals are strings (390.50),
+// to keep their precision.
+type MNBServiceClient interface {
+	// GetRates returns the rates of the publication days of the period, newest first.
+	GetRates(ctx context.Context, in *GetRatesRequest, opts ...grpc.CallOption) (*GetRatesResponse, error)
+	// StreamRates streams the rates of the publication days of the period, oldest first.
+	StreamRates(ctx context.Context, in *GetRatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DayRates], error)
+	// Convert an amount between currencies, through HUF.
+	Convert(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ConvertResponse, error)
+	// GetBaseRate returns the base rate in effect on the day, or the current one.
+	GetBaseRate(ctx context.Context, in *GetBaseRateRequest, opts ...grpc.CallOption) (*BaseRate, error)
+	// ListCurrencies returns all the currencies known by MNB.
+	ListCurrencies(ctx context.Context, in *ListCurrenciesRequest, opts ...grpc.CallOption) (*ListCurrenciesResponse, error)
+}
+
+type mNBServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMNBServiceClient(cc grpc.ClientConnInterface) MNBServiceClient {
+	return &mNBServiceClient{cc}
+}
+
+func (c *mNBServiceClient) GetRates(ctx context.Context, in *GetRatesRequest, opts ...grpc.CallOption) (*GetRatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRatesResponse)
+	err := c.cc.Invoke(ctx, MNBService_GetRates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mNBServiceClient) StreamRates(ctx context.Context, in *GetRatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DayRates], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MNBService_ServiceDesc.Streams[0], MNBService_StreamRates_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetRatesRequest, DayRates]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MNBService_StreamRatesClient = grpc.ServerStreamingClient[DayRates]
+
+func (c *mNBServiceClient) Convert(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ConvertResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConvertResponse)
+	err := c.cc.Invoke(ctx, MNBService_Convert_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mNBServiceClient) GetBaseRate(ctx context.Context, in *GetBaseRateRequest, opts ...grpc.CallOption) (*BaseRate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BaseRate)
+	err := c.cc.Invoke(ctx, MNBService_GetBaseRate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mNBServiceClient) ListCurrencies(ctx context.Context, in *ListCurrenciesRequest, opts ...grpc.CallOption) (*ListCurrenciesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCurrenciesResponse)
+	err := c.cc.Invoke(ctx, MNBService_ListCurrencies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MNBServiceServer is the server API for MNBService service.
+// All implementations must embed UnimplementedMNBServiceServer
+// for forward compatibility.
+//
+// MNBService serves the exchange rates and the base rate of the Hungarian National Bank.
+//
+// Days are ISO 8601 dates (2006-01-02), decimals are strings (390.50),
+// to keep their precision.
+type MNBServiceServer interface {
+	// GetRates returns the rates of the publication days of the period, newest first.
+	GetRates(context.Context, *GetRatesRequest) (*GetRatesResponse, error)
+	// StreamRates streams the rates of the publication days of the period, oldest first.
+	StreamRates(*GetRatesRequest, grpc.ServerStreamingServer[DayRates]) error
+	// Convert an amount between currencies, through HUF.
+	Convert(context.Context, *ConvertRequest) (*ConvertResponse, error)
+	// GetBaseRate returns the base rate in effect on the day, or the current one.
+	GetBaseRate(context.Context, *GetBaseRateRequest) (*BaseRate, error)
+	// ListCurrencies returns all the currencies known by MNB.
+	ListCurrencies(context.Context, *ListCurrenciesRequest) (*ListCurrenciesResponse, error)
+	mustEmbedUnimplementedMNBServiceServer()
+}
+
+// UnimplementedMNBServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMNBServiceServer struct{}
+
+func (UnimplementedMNBServiceServer) GetRates(context.Context, *GetRatesRequest) (*GetRatesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRates not implemented")
+}
+func (UnimplementedMNBServiceServer) StreamRates(*GetRatesRequest, grpc.ServerStreamingServer[DayRates]) error {
+	return status.Error(codes.Unimplemented, "method StreamRates not implemented")
+}
+func (UnimplementedMNBServiceServer) Convert(context.Context, *ConvertRequest) (*ConvertResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Convert not implemented")
+}
+func (UnimplementedMNBServiceServer) GetBaseRate(context.Context, *GetBaseRateRequest) (*BaseRate, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBaseRate not implemented")
+}
+func (UnimplementedMNBServiceServer) ListCurrencies(context.Context, *ListCurrenciesRequest) (*ListCurrenciesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListCurrencies not implemented")
+}
+func (UnimplementedMNBServiceServer) mustEmbedUnimplementedMNBServiceServer() {}
+func (UnimplementedMNBServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafeMNBServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MNBServiceServer will
+// result in compilation errors.
+type UnsafeMNBServiceServer interface {
+	mustEmbedUnimplementedMNBServiceServer()
+}
+
+func RegisterMNBServiceServer(s grpc.ServiceRegistrar, srv MNBServiceServer) {
+	// If the following call panics, it indicates UnimplementedMNBServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MNBService_ServiceDesc, srv)
+}
+
+func _MNBService_GetRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MNBServiceServer).GetRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MNBService_GetRates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MNBServiceServer).GetRates(ctx, req.(*GetRatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MNBService_StreamRates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetRatesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MNBServiceServer).StreamRates(m, &grpc.GenericServerStream[GetRatesRequest, DayRates]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MNBService_StreamRatesServer = grpc.ServerStreamingServer[DayRates]
+
+func _MNBService_Convert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MNBServiceServer).Convert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MNBService_Convert_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MNBServiceServer).Convert(ctx, req.(*ConvertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MNBService_GetBaseRate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBaseRateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MNBServiceServer).GetBaseRate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MNBService_GetBaseRate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MNBServiceServer).GetBaseRate(ctx, req.(*GetBaseRateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MNBService_ListCurrencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCurrenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MNBServiceServer).ListCurrencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MNBService_ListCurrencies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MNBServiceServer).ListCurrencies(ctx, req.(*ListCurrenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MNBService_ServiceDesc is the grpc.ServiceDesc for MNBService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MNBService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "mnbarf.v1.MNBService",
+	HandlerType: (*MNBServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetRates",
+			Handler:    _MNBService_GetRates_Handler,
+		},
+		{
+			MethodName: "Convert",
+			Handler:    _MNBService_Convert_Handler,
+		},
+		{
+			MethodName: "GetBaseRate",
+			Handler:    _MNBService_GetBaseRate_Handler,
+		},
+		{
+			MethodName: "ListCurrencies",
+			Handler:    _MNBService_ListCurrencies_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamRates",
+			Handler:       _MNBService_StreamRates_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "mnb.proto",
+}
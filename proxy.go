@@ -0,0 +1,140 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/tgulacsi/mnbarf/mnb"
+)
+
+// soapOperations maps the operations of arfolyamok.asmx and alapkamat.asmx to their SOAPAction prefix.
+var soapOperations = map[string]string{
+	"GetCurrencies":                 "http://www.mnb.hu/webservices/MNBArfolyamServiceSoap/",
+	"GetCurrencyUnits":              "http://www.mnb.hu/webservices/MNBArfolyamServiceSoap/",
+	"GetCurrentExchangeRates":       "http://www.mnb.hu/webservices/MNBArfolyamServiceSoap/",
+	"GetDateInterval":               "http://www.mnb.hu/webservices/MNBArfolyamServiceSoap/",
+	"GetExchangeRates":              "http://www.mnb.hu/webservices/MNBArfolyamServiceSoap/",
+	"GetInfo":                       "http://www.mnb.hu/webservices/MNBArfolyamServiceSoap/",
+	"GetCentralBankBaseRate":        "http://www.mnb.hu/webservices/MNBAlapkamatServiceSoap/",
+	"GetCurrentCentralBankBaseRate": "http://www.mnb.hu/webservices/MNBAlapkamatServiceSoap/",
+}
+
+// soapProxy answers the SOAP requests of the legacy MNB clients:
+// from m.Cache if it can, else forwards them to MNB, and if that fails, answers from stale.
+type soapProxy struct {
+	m mnb.MNB
+	// stale holds the last good answer for each request.
+	stale mnb.Cache
+}
+
+var rWhitespaceBetweenTags = regexp.MustCompile(`>\s+<`)
+
+func (p soapProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only SOAP POST requests are accepted", http.StatusMethodNotAllowed)
+		return
+	}
+	b, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		writeSOAPFault(w, "Client", err)
+		return
+	}
+	action := strings.Trim(r.Header.Get("SOAPAction"), `"`)
+	if action == "" { // SOAP 1.2
+		if _, params, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err == nil {
+			action = params["action"]
+		}
+	}
+	op := action[strings.LastIndexByte(action, '/')+1:]
+	if op == "" {
+		op = soapOperation(b)
+	}
+	prefix, ok := soapOperations[op]
+	if !ok {
+		writeSOAPFault(w, "Client", fmt.Errorf("unknown operation %q", op))
+		return
+	}
+	action = prefix + op
+	defaultURL := mnb.ArfolyamokURL
+	if strings.Contains(prefix, "Alapkamat") {
+		defaultURL = mnb.AlapkamatURL
+	}
+
+	// strip the XML header and the formatting, to have the same cache key for the same request
+	if bytes.HasPrefix(b, []byte("<?xml")) {
+		if i := bytes.Index(b, []byte("?>")); i >= 0 {
+			b = b[i+2:]
+		}
+	}
+	body := rWhitespaceBetweenTags.ReplaceAllString(strings.TrimSpace(string(b)), "><")
+	key := action + "\x00" + body
+
+	data, err := p.m.Call(r.Context(), defaultURL, action, body)
+	if err == nil {
+		if p.stale != nil {
+			p.stale.Set(key, data)
+		}
+	} else if p.stale != nil {
+		var ok bool
+		if data, ok = p.stale.Get(key); ok {
+			logger.Warn("answer from store", "action", action, "error", err)
+			err = nil
+		}
+	}
+	if err != nil {
+		logger.Error("call", "action", action, "error", err)
+		writeSOAPFault(w, "Server", err)
+		return
+	}
+	writeSOAPResult(w, op, data)
+}
+
+// soapOperation returns the name of the first element in the SOAP Body.
+func soapOperation(b []byte) string {
+	dec := xml.NewDecoder(bytes.NewReader(b))
+	var inBody bool
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return ""
+		}
+		if st, ok := tok.(xml.StartElement); ok {
+			if inBody {
+				return st.Name.Local
+			}
+			inBody = strings.EqualFold(st.Name.Local, "body")
+		}
+	}
+}
+
+// writeSOAPResult writes the answer in the same envelope as MNB does, with the data in a CDATA section.
+func writeSOAPResult(w http.ResponseWriter, op string, data []byte) {
+	w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+	var buf bytes.Buffer
+	buf.WriteString(`<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/"><s:Body><`)
+	buf.WriteString(op + `Response xmlns="http://www.mnb.hu/webservices/" xmlns:i="http://www.w3.org/2001/XMLSchema-instance"><`)
+	buf.WriteString(op + `Result><![CDATA[`)
+	buf.Write(bytes.ReplaceAll(data, []byte("]]>"), []byte("]]]]><![CDATA[>")))
+	buf.WriteString(`]]></` + op + `Result></` + op + `Response></s:Body></s:Envelope>`)
+	_, _ = w.Write(buf.Bytes())
+}
+
+func writeSOAPFault(w http.ResponseWriter, code string, err error) {
+	w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+	w.WriteHeader(http.StatusInternalServerError)
+	var buf bytes.Buffer
+	buf.WriteString(`<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/"><s:Body><s:Fault><faultcode>s:` + code + `</faultcode><faultstring>`)
+	_ = xml.EscapeText(&buf, []byte(err.Error()))
+	buf.WriteString(`</faultstring></s:Fault></s:Body></s:Envelope>`)
+	_, _ = w.Write(buf.Bytes())
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tgulacsi/mnbarf/mnb"
+)
+
+func TestSOAPProxyStale(t *testing.T) {
+	var calls atomic.Int32
+	var down atomic.Bool
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if down.Load() {
+			http.Error(w, "maintenance", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+		fmt.Fprint(w, `<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/"><s:Body><GetCurrentExchangeRatesResponse xmlns="http://www.mnb.hu/webservices/"><GetCurrentExchangeRatesResult><![CDATA[<MNBCurrentExchangeRates><Day date="2024-05-03"><Rate unit="1" curr="EUR">390,50</Rate></Day></MNBCurrentExchangeRates>]]></GetCurrentExchangeRatesResult></GetCurrentExchangeRatesResponse></s:Body></s:Envelope>`)
+	}))
+	defer upstream.Close()
+
+	const reqBody = `<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/" xmlns:web="http://www.mnb.hu/webservices/"><soapenv:Header/><soapenv:Body><web:GetCurrentExchangeRates/></soapenv:Body></soapenv:Envelope>`
+	post := func(p soapProxy, body string) *httptest.ResponseRecorder {
+		// the upstream calls are retried till the context is done
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		req := httptest.NewRequest("POST", "/arfolyamok.asmx", strings.NewReader(body)).WithContext(ctx)
+		req.Header.Set("SOAPAction", `"http://www.mnb.hu/webservices/MNBArfolyamServiceSoap/GetCurrentExchangeRates"`)
+		req.Header.Set("Content-Type", "text/xml; charset=utf-8")
+		rec := httptest.NewRecorder()
+		p.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for _, stale := range []mnb.Cache{mnb.NewMemoryCache(time.Hour), mnb.DirCache{Dir: t.TempDir()}} {
+		calls.Store(0)
+		down.Store(false)
+		p := soapProxy{m: mnb.NewMNB(upstream.URL, nil, nil), stale: stale}
+
+		rec := post(p, reqBody)
+		if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `<Rate unit="1" curr="EUR">390,50</Rate>`) {
+			t.Fatalf("%T: got %d: %s", stale, rec.Code, rec.Body.String())
+		}
+
+		down.Store(true)
+		// the same request, formatted differently
+		rec = post(p, `<?xml version="1.0" encoding="utf-8"?>`+"\n"+strings.ReplaceAll(reqBody, "><", ">\n  <"))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%T: got %d, wanted the stale answer: %s", stale, rec.Code, rec.Body.String())
+		}
+		if body := rec.Body.String(); !strings.Contains(body, "<GetCurrentExchangeRatesResult><![CDATA[<MNBCurrentExchangeRates>") ||
+			!strings.Contains(body, "390,50") {
+			t.Errorf("%T: got stale answer %s", stale, body)
+		}
+		if n := calls.Load(); n < 2 {
+			t.Errorf("%T: got %d upstream calls, the second request should be tried upstream first", stale, n)
+		}
+
+		// nothing stored for this one
+		rec = post(p, strings.ReplaceAll(reqBody, "<soapenv:Header/>", "<soapenv:Header></soapenv:Header>"))
+		if rec.Code != http.StatusInternalServerError || !strings.Contains(rec.Body.String(), "<faultcode>s:Server</faultcode>") {
+			t.Errorf("%T: got %d, wanted a SOAP fault: %s", stale, rec.Code, rec.Body.String())
+		}
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/tgulacsi/mnbarf/mnb"
+)
+
+// readFlows reads the ledger of foreign currency flows from the CSV with a date, currency
+// and (signed) amount header, and an optional id column.
+func readFlows(r io.Reader) ([]mnb.Flow, error) {
+	cr := csvReader(r)
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+	cols := csvColumns(header)
+	for _, c := range []string{"date", "currency", "amount"} {
+		if _, ok := cols[c]; !ok {
+			return nil, fmt.Errorf("no %q column in %q", c, header)
+		}
+	}
+	get := func(rec []string, col string) string {
+		if i, ok := cols[col]; ok && i < len(rec) {
+			return strings.TrimSpace(rec[i])
+		}
+		return ""
+	}
+	var flows []mnb.Flow
+	for {
+		rec, err := cr.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return flows, nil
+			}
+			return flows, err
+		}
+		line, _ := cr.FieldPos(0)
+		f := mnb.Flow{ID: get(rec, "id"), Currency: strings.ToUpper(get(rec, "currency"))}
+		if f.ID == "" {
+			f.ID = strconv.Itoa(line)
+		}
+		if err := f.Day.UnmarshalText([]byte(get(rec, "date"))); err != nil {
+			return flows, fmt.Errorf("line %d: date: %w", line, err)
+		}
+		if err := f.Amount.UnmarshalText([]byte(get(rec, "amount"))); err != nil {
+			return flows, fmt.Errorf("line %d: amount: %w", line, err)
+		}
+		flows = append(flows, f)
+	}
+}
+
+// printRealization prints the realized gains and losses in the output format:
+// json and the templates get the whole report (the "row" template each flow),
+// csv, html and markdown the flows, or the per currency totals.
+func printRealization(w io.Writer, report mnb.RealizationReport, totals bool, outFormat string) error {
+	bw := bufio.NewWriter(w)
+	defer bw.Flush()
+
+	switch outFormat {
+	case "json":
+		enc := json.NewEncoder(bw)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+
+	case "csv", "html", "markdown", "md":
+		var t table
+		if totals {
+			t = table{
+				Title:   "Realized FX gains and losses (" + string(report.Method) + ")",
+				Columns: []string{"currency", "count", "gain_loss", "balance", "book_value"},
+			}
+			for _, r := range report.Totals {
+				t.Rows = append(t.Rows, []string{
+					r.Currency, strconv.Itoa(r.Count), outLocale.FormatDouble(r.GainLoss),
+					outLocale.FormatDouble(r.Balance), outLocale.FormatDouble(r.BookValue),
+				})
+			}
+		} else {
+			t = table{
+				Title: "Realized FX gains and losses (" + string(report.Method) + ")",
+				Columns: []string{"id", "date", "currency", "amount", "rate_day", "rate", "value",
+					"cost", "gain_loss", "balance", "book_value"},
+			}
+			for _, r := range report.Flows {
+				t.Rows = append(t.Rows, []string{
+					r.ID, outLocale.FormatDate(r.Day), r.Currency, outLocale.FormatDouble(r.Amount),
+					outLocale.FormatDate(r.RateDay), outLocale.FormatDouble(r.Rate), outLocale.FormatDouble(r.Value),
+					outLocale.FormatDouble(r.Cost), outLocale.FormatDouble(r.GainLoss),
+					outLocale.FormatDouble(r.Balance), outLocale.FormatDouble(r.BookValue),
+				})
+			}
+		}
+		switch outFormat {
+		case "csv":
+			return t.WriteCSV(bw)
+		case "html":
+			return t.WriteHTML(bw)
+		}
+		return t.WriteMarkdown(bw)
+
+	case "sql":
+		return fmt.Errorf("the sql format is not supported for realized gains and losses")
+
+	default: // template
+		tmpl, err := parseOutTemplate(outFormat)
+		if err != nil {
+			logger.Info("template parse", "error", err)
+			return err
+		}
+		if err := executeOutTemplate(bw, tmpl, report, report.Flows); err != nil {
+			logger.Info("template execute", "error", err)
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,275 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"html/template"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tgulacsi/mnbarf/mnb"
+)
+
+// report is the data of the html and markdown outputs:
+// a table with one row per day and one column per series, and a summary of each series.
+type report struct {
+	Title     string
+	Columns   []string
+	Rows      []reportRow
+	Summaries []reportSummary
+}
+
+type reportRow struct {
+	Day   string
+	Cells []string
+}
+
+type reportSummary struct {
+	Name                  string
+	Count                 int
+	First, Last, Min, Max string
+	FirstDay, LastDay     string
+	MinDay, MaxDay        string
+	Mean, Change          string
+	Sparkline             template.HTML
+}
+
+func newRatesReport(days []mnb.DayRates) (report, error) {
+	series := mnb.SplitSeries(days)
+	// printDayRates adds the constant HUF rate to each day.
+	series = slices.DeleteFunc(series, func(s mnb.Series) bool { return s.Currency == "HUF" })
+	names := make([]string, len(series))
+	for i, s := range series {
+		names[i] = s.Currency
+		if s.Unit > 1 {
+			names[i] += " (" + strconv.Itoa(s.Unit) + ")"
+		}
+	}
+	return newReport("MNB exchange rates (HUF)", names, series)
+}
+
+func newBaseRatesReport(rates []mnb.MNBBaseRate) (report, error) {
+	return newReport("MNB base rate (%)", []string{"base rate"}, []mnb.Series{mnb.BaseRateSeries(rates)})
+}
+
+func newReport(title string, names []string, series []mnb.Series) (report, error) {
+	rep := report{Columns: names}
+	var days []time.Time
+	for _, s := range series {
+		for _, o := range s.Observations {
+			days = append(days, time.Time(o.Day))
+		}
+	}
+	slices.SortFunc(days, time.Time.Compare)
+	days = slices.CompactFunc(days, time.Time.Equal)
+	if len(days) != 0 {
+		title += " " + outLocale.FormatDate(mnb.Date(days[0])) + " - " + outLocale.FormatDate(mnb.Date(days[len(days)-1]))
+	}
+	rep.Title = title
+
+	rep.Rows = make([]reportRow, len(days))
+	for i, d := range days {
+		rep.Rows[i] = reportRow{Day: outLocale.FormatDate(mnb.Date(d)), Cells: make([]string, len(series))}
+	}
+	for j, s := range series {
+		i := 0
+		for _, o := range s.Observations {
+			for !days[i].Equal(time.Time(o.Day)) {
+				i++
+			}
+			rep.Rows[i].Cells[j] = outLocale.FormatDouble(o.Rate)
+		}
+
+		sum, err := s.Summarize()
+		if err != nil {
+			return rep, fmt.Errorf("summarize %s: %w", names[j], err)
+		}
+		if sum.Count == 0 {
+			continue
+		}
+		rep.Summaries = append(rep.Summaries, newReportSummary(names[j], s, sum))
+	}
+	return rep, nil
+}
+
+func newReportSummary(name string, s mnb.Series, sum mnb.Summary) reportSummary {
+	return reportSummary{
+		Name: name, Count: sum.Count,
+		First: outLocale.FormatDouble(sum.First.Rate), FirstDay: outLocale.FormatDate(sum.First.Day),
+		Last: outLocale.FormatDouble(sum.Last.Rate), LastDay: outLocale.FormatDate(sum.Last.Day),
+		Min: outLocale.FormatDouble(sum.Min.Rate), MinDay: outLocale.FormatDate(sum.Min.Day),
+		Max: outLocale.FormatDouble(sum.Max.Rate), MaxDay: outLocale.FormatDate(sum.Max.Day),
+		Mean:      outLocale.FormatDouble(sum.Mean.Round(4)),
+		Change:    outLocale.FormatDouble(sum.Change.Round(2)),
+		Sparkline: sparkline(s, 120, 24),
+	}
+}
+
+// sparkline returns an inline SVG polyline of the series.
+// The missing rates are skipped, the others keep their place on the x axis.
+func sparkline(s mnb.Series, width, height int) template.HTML {
+	var values []float64
+	for _, o := range s.Observations {
+		if o.Rate.Decimal != nil {
+			values = append(values, o.Rate.Float64())
+		}
+	}
+	if len(values) == 0 {
+		return ""
+	}
+	lo, hi := slices.Min(values), slices.Max(values)
+	var buf strings.Builder
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %[1]d %[2]d"><polyline fill="none" stroke="currentColor" stroke-width="1.5" points="`, width, height)
+	n, sep := len(s.Observations), ""
+	for i, o := range s.Observations {
+		if o.Rate.Decimal == nil {
+			continue
+		}
+		x := float64(width) / 2
+		if n > 1 {
+			x = float64(i) * float64(width) / float64(n-1)
+		}
+		y := float64(height) / 2
+		if v := o.Rate.Float64(); hi > lo {
+			y = 1 + (hi-v)*float64(height-2)/(hi-lo)
+		}
+		fmt.Fprintf(&buf, "%s%.1f,%.1f", sep, x, y)
+		sep = " "
+	}
+	buf.WriteString(`"/></svg>`)
+	return template.HTML(buf.String())
+}
+
+// reportCSS is the style sheet of the HTML outputs.
+const reportCSS = `<style>
+body { font-family: sans-serif; color: #222; }
+table { border-collapse: collapse; margin-bottom: 1.5em; }
+th, td { border: 1px solid #ccc; padding: 0.2em 0.6em; }
+th { background: #f0f0f0; }
+td.num { text-align: right; font-variant-numeric: tabular-nums; }
+td.spark { color: #1f5fa8; }
+</style>
+`
+
+var htmlReportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+` + reportCSS + `</head>
+<body>
+<h1>{{.Title}}</h1>
+<h2>Summary</h2>
+<table>
+<tr><th></th><th>First</th><th>Last</th><th>Min</th><th>Max</th><th>Mean</th><th>Change %</th><th>Days</th><th></th></tr>
+{{range .Summaries}}<tr><th>{{.Name}}</th><td class="num" title="{{.FirstDay}}">{{.First}}</td><td class="num" title="{{.LastDay}}">{{.Last}}</td><td class="num" title="{{.MinDay}}">{{.Min}}</td><td class="num" title="{{.MaxDay}}">{{.Max}}</td><td class="num">{{.Mean}}</td><td class="num">{{.Change}}</td><td class="num">{{.Count}}</td><td class="spark">{{.Sparkline}}</td></tr>
+{{end}}</table>
+<h2>Rates</h2>
+<table>
+<tr><th>Date</th>{{range .Columns}}<th>{{.}}</th>{{end}}</tr>
+{{range .Rows}}<tr><td>{{.Day}}</td>{{range .Cells}}<td class="num">{{.}}</td>{{end}}</tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+func (rep report) WriteHTML(w io.Writer) error {
+	return htmlReportTemplate.Execute(w, rep)
+}
+
+func (rep report) WriteMarkdown(w io.Writer) error {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "# %s\n\n## Summary\n\n", rep.Title)
+	buf.WriteString("| | First | Last | Min | Max | Mean | Change % | Days |\n|---|--:|--:|--:|--:|--:|--:|--:|\n")
+	for _, s := range rep.Summaries {
+		fmt.Fprintf(&buf, "| %s | %s (%s) | %s (%s) | %s (%s) | %s (%s) | %s | %s | %d |\n",
+			s.Name, s.First, s.FirstDay, s.Last, s.LastDay, s.Min, s.MinDay, s.Max, s.MaxDay,
+			s.Mean, s.Change, s.Count)
+	}
+	buf.WriteString("\n## Rates\n\n| Date |")
+	for _, c := range rep.Columns {
+		buf.WriteString(" " + c + " |")
+	}
+	buf.WriteString("\n|---|")
+	for range rep.Columns {
+		buf.WriteString("--:|")
+	}
+	buf.WriteByte('\n')
+	for _, row := range rep.Rows {
+		buf.WriteString("| " + row.Day + " |")
+		for _, c := range row.Cells {
+			buf.WriteString(" " + c + " |")
+		}
+		buf.WriteByte('\n')
+	}
+	_, err := io.WriteString(w, buf.String())
+	return err
+}
+
+// table is a titled table of computed results, for the csv, html and markdown outputs.
+type table struct {
+	Title   string
+	Columns []string
+	Rows    [][]string
+}
+
+var htmlTableTemplate = template.Must(template.New("table").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+` + reportCSS + `</head>
+<body>
+<h1>{{.Title}}</h1>
+<table>
+<tr>{{range .Columns}}<th>{{.}}</th>{{end}}</tr>
+{{range .Rows}}<tr>{{range $i, $c := .}}{{if $i}}<td class="num">{{$c}}</td>{{else}}<td>{{$c}}</td>{{end}}{{end}}</tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+func (t table) WriteHTML(w io.Writer) error {
+	return htmlTableTemplate.Execute(w, t)
+}
+
+func (t table) WriteMarkdown(w io.Writer) error {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "# %s\n\n|", t.Title)
+	for _, c := range t.Columns {
+		buf.WriteString(" " + c + " |")
+	}
+	buf.WriteString("\n|")
+	for i := range t.Columns {
+		if i == 0 {
+			buf.WriteString("---|")
+		} else {
+			buf.WriteString("--:|")
+		}
+	}
+	buf.WriteByte('\n')
+	for _, row := range t.Rows {
+		buf.WriteString("|")
+		for _, c := range row {
+			buf.WriteString(" " + c + " |")
+		}
+		buf.WriteByte('\n')
+	}
+	_, err := io.WriteString(w, buf.String())
+	return err
+}
+
+func (t table) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = csvSeparator()
+	_ = cw.Write(t.Columns)
+	_ = cw.WriteAll(t.Rows)
+	return cw.Error()
+}
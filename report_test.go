@@ -0,0 +1,50 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tgulacsi/mnbarf/mnb"
+)
+
+func TestSparkline(t *testing.T) {
+	series := func(rates ...string) mnb.Series {
+		var s mnb.Series
+		for _, r := range rates {
+			var o mnb.Observation
+			if r != "" {
+				o.Rate = testRate(t, "EUR", 1, r).Rate
+			}
+			s.Observations = append(s.Observations, o)
+		}
+		return s
+	}
+	for _, tc := range []struct {
+		Name   string
+		Series mnb.Series
+		Points string
+	}{
+		{Name: "rising", Series: series("390", "391", "392"), Points: "0.0,9.0 50.0,5.0 100.0,1.0"},
+		{Name: "flat", Series: series("390", "390"), Points: "0.0,5.0 100.0,5.0"},
+		{Name: "one", Series: series("390"), Points: "50.0,5.0"},
+		// the missing rates are not plotted as zeros
+		{Name: "missing", Series: series("390", "", "392", ""), Points: "0.0,9.0 66.7,1.0"},
+		{Name: "all missing", Series: series("", "")},
+		{Name: "empty"},
+	} {
+		got := string(sparkline(tc.Series, 100, 10))
+		if tc.Points == "" {
+			if got != "" {
+				t.Errorf("%s: got %s, wanted nothing", tc.Name, got)
+			}
+			continue
+		}
+		if !strings.HasPrefix(got, `<svg `) || !strings.Contains(got, ` points="`+tc.Points+`"/>`) {
+			t.Errorf("%s: got %s, wanted points %q", tc.Name, got, tc.Points)
+		}
+	}
+}
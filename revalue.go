@@ -0,0 +1,174 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/tgulacsi/mnbarf/mnb"
+)
+
+// csvReader returns a csv.Reader of r, with the separator (comma, semicolon or tab) of its first line.
+func csvReader(r io.Reader) *csv.Reader {
+	br := bufio.NewReader(r)
+	first, _ := br.Peek(4096)
+	if i := bytes.IndexByte(first, '\n'); i >= 0 {
+		first = first[:i]
+	}
+	cr := csv.NewReader(br)
+	cr.ReuseRecord = true
+	cr.FieldsPerRecord = -1
+	for _, sep := range []rune{';', '\t'} {
+		if bytes.ContainsRune(first, sep) {
+			cr.Comma = sep
+			break
+		}
+	}
+	return cr
+}
+
+// openInput opens the named file, or the standard input for "-".
+func openInput(fn string) (io.ReadCloser, error) {
+	if fn == "-" {
+		return io.NopCloser(os.Stdin), nil
+	}
+	return os.Open(fn)
+}
+
+// csvColumns returns the index of each column of the header, by its lowercased name.
+func csvColumns(header []string) map[string]int {
+	cols := make(map[string]int, len(header))
+	for i, h := range header {
+		cols[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))] = i
+	}
+	return cols
+}
+
+// readOpenItems reads the open items from the CSV with an id, currency, amount
+// and booked or booking_date header.
+func readOpenItems(r io.Reader) ([]mnb.OpenItem, error) {
+	cr := csvReader(r)
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+	cols := csvColumns(header)
+	for _, c := range []string{"id", "currency", "amount"} {
+		if _, ok := cols[c]; !ok {
+			return nil, fmt.Errorf("no %q column in %q", c, header)
+		}
+	}
+	_, hasBooked := cols["booked"]
+	_, hasBookingDate := cols["booking_date"]
+	if !hasBooked && !hasBookingDate {
+		return nil, fmt.Errorf("neither \"booked\" nor \"booking_date\" column in %q", header)
+	}
+	get := func(rec []string, col string) string {
+		if i, ok := cols[col]; ok && i < len(rec) {
+			return strings.TrimSpace(rec[i])
+		}
+		return ""
+	}
+	var items []mnb.OpenItem
+	for {
+		rec, err := cr.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return items, nil
+			}
+			return items, err
+		}
+		line, _ := cr.FieldPos(0)
+		item := mnb.OpenItem{ID: get(rec, "id"), Currency: strings.ToUpper(get(rec, "currency"))}
+		if err := item.Amount.UnmarshalText([]byte(get(rec, "amount"))); err != nil {
+			return items, fmt.Errorf("line %d: amount: %w", line, err)
+		}
+		if s := get(rec, "booked"); s != "" {
+			if err := item.Booked.UnmarshalText([]byte(s)); err != nil {
+				return items, fmt.Errorf("line %d: booked: %w", line, err)
+			}
+		} else if s := get(rec, "booking_date"); s != "" {
+			if err := item.BookingDay.UnmarshalText([]byte(s)); err != nil {
+				return items, fmt.Errorf("line %d: booking_date: %w", line, err)
+			}
+		} else {
+			return items, fmt.Errorf("line %d: neither booked nor booking_date", line)
+		}
+		items = append(items, item)
+	}
+}
+
+// printRevaluation prints the revaluation in the output format:
+// json and the templates get the whole report (the "row" template each item),
+// csv, html and markdown the items, or the per currency totals.
+func printRevaluation(w io.Writer, report mnb.RevaluationReport, totals bool, outFormat string) error {
+	bw := bufio.NewWriter(w)
+	defer bw.Flush()
+
+	switch outFormat {
+	case "json":
+		enc := json.NewEncoder(bw)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+
+	case "csv", "html", "markdown", "md":
+		var t table
+		if totals {
+			t = table{
+				Title:   "FX revaluation totals on " + outLocale.FormatDate(report.Day),
+				Columns: []string{"currency", "count", "amount", "booked", "value", "gain_loss"},
+			}
+			for _, r := range report.Totals {
+				t.Rows = append(t.Rows, []string{
+					r.Currency, strconv.Itoa(r.Count), outLocale.FormatDouble(r.Amount),
+					outLocale.FormatDouble(r.Booked), outLocale.FormatDouble(r.Value), outLocale.FormatDouble(r.GainLoss),
+				})
+			}
+		} else {
+			t = table{
+				Title:   "FX revaluation on " + outLocale.FormatDate(report.Day),
+				Columns: []string{"id", "currency", "amount", "booked", "rate_day", "rate", "value", "gain_loss"},
+			}
+			for _, r := range report.Items {
+				t.Rows = append(t.Rows, []string{
+					r.ID, r.Currency, outLocale.FormatDouble(r.Amount), outLocale.FormatDouble(r.Booked),
+					outLocale.FormatDate(r.RateDay), outLocale.FormatDouble(r.Rate),
+					outLocale.FormatDouble(r.Value), outLocale.FormatDouble(r.GainLoss),
+				})
+			}
+		}
+		switch outFormat {
+		case "csv":
+			return t.WriteCSV(bw)
+		case "html":
+			return t.WriteHTML(bw)
+		}
+		return t.WriteMarkdown(bw)
+
+	case "sql":
+		return fmt.Errorf("the sql format is not supported for revaluation")
+
+	default: // template
+		tmpl, err := parseOutTemplate(outFormat)
+		if err != nil {
+			logger.Info("template parse", "error", err)
+			return err
+		}
+		if err := executeOutTemplate(bw, tmpl, report, report.Items); err != nil {
+			logger.Info("template execute", "error", err)
+			return err
+		}
+	}
+	return nil
+}
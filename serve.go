@@ -0,0 +1,301 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tgulacsi/mnbarf/mnb"
+)
+
+//go:embed openapi.json
+var openAPIDoc []byte
+
+// uiFS is the single page web UI, which uses the JSON API only.
+//
+//go:embed ui
+var uiFS embed.FS
+
+// apiServer is the JSON HTTP API in front of the MNB services.
+type apiServer struct {
+	wsC mnb.MNBArfolyamService
+	wsR mnb.MNBAlapkamatService
+	mux *http.ServeMux
+}
+
+func newAPIServer(wsC mnb.MNBArfolyamService, wsR mnb.MNBAlapkamatService) *apiServer {
+	s := apiServer{wsC: wsC, wsR: wsR, mux: http.NewServeMux()}
+	s.mux.HandleFunc("GET /rates", s.handleRates)
+	s.mux.HandleFunc("GET /rates/current", s.handleCurrentRates)
+	s.mux.HandleFunc("GET /convert", s.handleConvert)
+	s.mux.HandleFunc("GET /baserate", s.handleBaseRate)
+	s.mux.HandleFunc("GET /baserate/at/{date}", s.handleBaseRateAt)
+	s.mux.HandleFunc("GET /currencies", s.handleCurrencies)
+	s.mux.HandleFunc("GET /info", s.handleInfo)
+	s.mux.HandleFunc("GET /feed/rates.atom", s.handleRatesFeed)
+	s.mux.HandleFunc("GET /feed/baserate.atom", s.handleBaseRateFeed)
+	s.mux.HandleFunc("GET /openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(openAPIDoc)
+	})
+	s.mux.Handle("GET /ui/", http.FileServerFS(uiFS))
+	s.mux.Handle("GET /{$}", http.RedirectHandler("ui/", http.StatusFound))
+	return &s
+}
+
+func (s *apiServer) ServeHTTP(w http.ResponseWriter, r *http.Request) { s.mux.ServeHTTP(w, r) }
+
+// errBadRequest marks the errors caused by the request parameters.
+var errBadRequest = errors.New("bad request")
+
+func writeJSON(w http.ResponseWriter, r *http.Request, v any, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		code := http.StatusBadGateway
+		if errors.Is(err, errBadRequest) {
+			code = http.StatusBadRequest
+		} else if errors.Is(err, mnb.ErrNoRate) {
+			code = http.StatusNotFound
+		}
+		logger.Info("serve", "url", r.URL.String(), "code", code, "error", err)
+		w.WriteHeader(code)
+		v = struct{ Error string }{err.Error()}
+	}
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger.Info("encode", "url", r.URL.String(), "error", err)
+	}
+}
+
+// queryDates parses the from and to query parameters, with the defaults of parseDates.
+func queryDates(r *http.Request) (begin, end time.Time, err error) {
+	q := r.URL.Query()
+	if begin, end, err = parseDates(q.Get("from"), q.Get("to")); err != nil {
+		err = fmt.Errorf("%w: %w", errBadRequest, err)
+	}
+	return begin, end, err
+}
+
+// queryCurrencies returns the currency query parameters, which can be repeated or comma separated.
+func queryCurrencies(r *http.Request) []string {
+	var currencies []string
+	for _, v := range r.URL.Query()["currency"] {
+		for _, c := range strings.Split(v, ",") {
+			if c = strings.ToUpper(strings.TrimSpace(c)); c != "" {
+				currencies = append(currencies, c)
+			}
+		}
+	}
+	return currencies
+}
+
+func (s *apiServer) handleRates(w http.ResponseWriter, r *http.Request) {
+	begin, end, err := queryDates(r)
+	if err != nil {
+		writeJSON(w, r, nil, err)
+		return
+	}
+	currencies := queryCurrencies(r)
+	if len(currencies) == 0 {
+		writeJSON(w, r, nil, fmt.Errorf("%w: at least one currency is needed", errBadRequest))
+		return
+	}
+	days, err := s.wsC.GetExchangeRates(r.Context(), begin, end, currencies...)
+	format := r.URL.Query().Get("format")
+	if err != nil || format == "" || format == "json" {
+		writeJSON(w, r, days, err)
+		return
+	}
+	fn := "mnb-rates-" + begin.Format("20060102") + "-" + end.Format("20060102") + "." + format
+	switch format {
+	case "csv":
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="`+fn+`"`)
+		err = printDayRates(w, days, "csv")
+	case "xlsx":
+		w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+		w.Header().Set("Content-Disposition", `attachment; filename="`+fn+`"`)
+		err = writeXLSX(w, "MNB rates", ratesSheet(days))
+	default:
+		writeJSON(w, r, nil, fmt.Errorf("%w: format=%q: json, csv or xlsx is accepted", errBadRequest, format))
+		return
+	}
+	if err != nil {
+		logger.Info("write", "url", r.URL.String(), "error", err)
+	}
+}
+
+// ratesSheet returns the days as the rows of a sheet: a header, then a row for each day (oldest first),
+// with a column for each currency.
+func ratesSheet(days []mnb.DayRates) [][]any {
+	series := mnb.SplitSeries(days)
+	header := []any{"Date"}
+	for _, s := range series {
+		name := s.Currency
+		if s.Unit > 1 {
+			name += " (" + strconv.Itoa(s.Unit) + ")"
+		}
+		header = append(header, name)
+	}
+	idx := make(map[mnb.Date]int)
+	for _, s := range series {
+		for _, o := range s.Observations {
+			idx[o.Day] = 0
+		}
+	}
+	dates := slices.SortedFunc(maps.Keys(idx), func(a, b mnb.Date) int { return time.Time(a).Compare(time.Time(b)) })
+	rows := make([][]any, 1, 1+len(dates))
+	rows[0] = header
+	for i, d := range dates {
+		idx[d] = i + 1
+		row := make([]any, len(header))
+		row[0] = d
+		rows = append(rows, row)
+	}
+	for j, s := range series {
+		for _, o := range s.Observations {
+			rows[idx[o.Day]][j+1] = o.Rate
+		}
+	}
+	return rows
+}
+
+func (s *apiServer) handleCurrentRates(w http.ResponseWriter, r *http.Request) {
+	day, err := s.wsC.GetCurrentExchangeRates(r.Context())
+	writeJSON(w, r, day, err)
+}
+
+// conversion is the answer of /convert.
+type conversion struct {
+	Amount   mnb.Double
+	From, To string
+	Day      mnb.Date
+	Rate     mnb.Double
+	Result   mnb.Double
+}
+
+// ratesFor returns the rates of the day (the current rates if day is zero),
+// with the last publication day's rates if there was no publication on that day.
+func ratesFor(ctx context.Context, wsC mnb.MNBArfolyamService, day time.Time, currencies ...string) (mnb.DayRates, error) {
+	if day.IsZero() {
+		return wsC.GetCurrentExchangeRates(ctx)
+	}
+	var need []string
+	for _, c := range currencies {
+		if c != "HUF" {
+			need = append(need, c)
+		}
+	}
+	if len(need) == 0 {
+		return mnb.DayRates{Day: mnb.Date(day)}, nil
+	}
+	return wsC.GetExchangeRatesAt(ctx, day, need...)
+}
+
+func (s *apiServer) handleConvert(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	from, to := strings.ToUpper(q.Get("from")), strings.ToUpper(q.Get("to"))
+	if from == "" {
+		writeJSON(w, r, nil, fmt.Errorf("%w: from is needed", errBadRequest))
+		return
+	}
+	if to == "" {
+		to = "HUF"
+	}
+	amountS := q.Get("amount")
+	if amountS == "" {
+		amountS = "1"
+	}
+	amount, err := mnb.NewDoubleFromString(strings.Replace(amountS, ",", ".", 1))
+	if err != nil {
+		writeJSON(w, r, nil, fmt.Errorf("%w: amount=%q: %w", errBadRequest, amountS, err))
+		return
+	}
+	var day time.Time
+	if s := q.Get("date"); s != "" {
+		if day, err = time.Parse("2006-01-02", s); err != nil {
+			writeJSON(w, r, nil, fmt.Errorf("%w: date=%q: %w", errBadRequest, s, err))
+			return
+		}
+	}
+	rates, err := ratesFor(r.Context(), s.wsC, day, from, to)
+	if err != nil {
+		writeJSON(w, r, nil, err)
+		return
+	}
+	res := conversion{Amount: amount, From: from, To: to, Day: rates.Day}
+	if res.Rate, err = rates.CrossRate(from, to); err == nil {
+		res.Result, err = rates.Convert(amount, from, to)
+		res.Rate, res.Result = res.Rate.Round(8), res.Result.Round(4)
+	}
+	writeJSON(w, r, res, err)
+}
+
+func (s *apiServer) handleBaseRate(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("from") == "" && q.Get("to") == "" {
+		rate, err := s.wsR.GetCurrentBaseRate(r.Context())
+		writeJSON(w, r, rate, err)
+		return
+	}
+	begin, end, err := queryDates(r)
+	if err != nil {
+		writeJSON(w, r, nil, err)
+		return
+	}
+	rates, err := s.wsR.GetBaseRates(r.Context(), begin, end)
+	writeJSON(w, r, rates, err)
+}
+
+func (s *apiServer) handleBaseRateAt(w http.ResponseWriter, r *http.Request) {
+	day, err := time.Parse("2006-01-02", r.PathValue("date"))
+	if err != nil {
+		writeJSON(w, r, nil, fmt.Errorf("%w: %w", errBadRequest, err))
+		return
+	}
+	rate, err := s.wsR.GetBaseRateAt(r.Context(), day)
+	writeJSON(w, r, rate, err)
+}
+
+func (s *apiServer) handleCurrencies(w http.ResponseWriter, r *http.Request) {
+	currencies, err := s.wsC.GetCurrencies(r.Context())
+	writeJSON(w, r, currencies, err)
+}
+
+func (s *apiServer) handleInfo(w http.ResponseWriter, r *http.Request) {
+	info, err := s.wsC.GetInfo(r.Context())
+	writeJSON(w, r, info, err)
+}
+
+// listenAndServe serves the handler on addr till the context is canceled.
+func listenAndServe(ctx context.Context, addr string, handler http.Handler) error {
+	srv := http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+	go func() {
+		<-ctx.Done()
+		shutCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutCtx)
+	}()
+	logger.Info("listening", "addr", addr)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
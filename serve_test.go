@@ -0,0 +1,152 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/tgulacsi/mnbarf/mnb"
+)
+
+// newTestAPIServer returns the API server in front of the fake.
+func newTestAPIServer(t *testing.T, fake *fakeMNB) *apiServer {
+	t.Helper()
+	srv := fake.serve(t)
+	return newAPIServer(mnb.NewMNBArfolyamService(srv.URL, nil, nil), mnb.NewMNBAlapkamatService(srv.URL, nil, nil))
+}
+
+// getJSON GETs the path, checks the status code and decodes the JSON answer into v.
+func getJSON(t *testing.T, api http.Handler, path string, code int, v any) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	api.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+	if rec.Code != code {
+		t.Fatalf("%s: got %d, wanted %d: %s", path, rec.Code, code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("%s: got Content-Type %q", path, ct)
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), v); err != nil {
+		t.Fatalf("%s: %+v\n%s", path, err, rec.Body.String())
+	}
+}
+
+func TestServeRates(t *testing.T) {
+	fake := fakeMNB{Days: testRates(t,
+		"2024-05-02 EUR=392.00 JPY/100=235.10 USD=366.00",
+		"2024-05-03 EUR=390.50 JPY/100=236.50 USD=364.10",
+	)}
+	api := newTestAPIServer(t, &fake)
+
+	var days []mnb.DayRates
+	getJSON(t, api, "/rates?from=2024-05-01&to=2024-05-03&currency=eur,JPY", http.StatusOK, &days)
+	if len(days) != 2 {
+		t.Fatalf("got %d days, wanted 2", len(days))
+	}
+	if got := days[1].Day.String(); got != "2024-05-03" {
+		t.Errorf("got day %s", got)
+	}
+	if len(days[1].Rates) != 2 {
+		t.Fatalf("got %+v, wanted EUR and JPY", days[1].Rates)
+	}
+	jpy, ok := days[1].Find("JPY")
+	if !ok || jpy.Unit != 100 {
+		t.Fatalf("got JPY %+v", jpy)
+	}
+	checkDouble(t, "JPY", jpy.Rate, "236.50")
+
+	rec := httptest.NewRecorder()
+	api.ServeHTTP(rec, httptest.NewRequest("GET", "/rates?from=2024-05-03&to=2024-05-03&currency=USD&format=csv", nil))
+	if ct := rec.Header().Get("Content-Type"); rec.Code != http.StatusOK || !strings.HasPrefix(ct, "text/csv") {
+		t.Fatalf("got %d %q: %s", rec.Code, ct, rec.Body.String())
+	}
+	if body := rec.Body.String(); !strings.Contains(body, "USD") || strings.Contains(body, "EUR") {
+		t.Errorf("got csv %q", body)
+	}
+}
+
+func TestServeConvert(t *testing.T) {
+	fake := fakeMNB{Days: testRates(t, "2024-05-03 EUR=390.50 JPY/100=236.50")}
+	api := newTestAPIServer(t, &fake)
+	for _, tc := range []struct {
+		Path, Day, Rate, Result string
+	}{
+		{Path: "/convert?from=EUR&amount=100&date=2024-05-03", Day: "2024-05-03", Rate: "390.50", Result: "39050"},
+		// on Saturday the rates of Friday are used
+		{Path: "/convert?from=eur&amount=2,5&date=2024-05-04", Day: "2024-05-03", Rate: "390.50", Result: "976.25"},
+		{Path: "/convert?from=HUF&to=JPY&amount=2365&date=2024-05-03", Day: "2024-05-03", Rate: "0.42283298", Result: "1000"},
+		{Path: "/convert?from=EUR&to=JPY&date=2024-05-03", Day: "2024-05-03", Rate: "165.11627907", Result: "165.1163"},
+		{Path: "/convert?from=EUR&amount=10", Day: "2024-05-03", Rate: "390.50", Result: "3905"},
+	} {
+		var got conversion
+		getJSON(t, api, tc.Path, http.StatusOK, &got)
+		if got.Day.String() != tc.Day {
+			t.Errorf("%s: got day %s, wanted %s", tc.Path, got.Day, tc.Day)
+		}
+		checkDouble(t, tc.Path+" rate", got.Rate, tc.Rate)
+		checkDouble(t, tc.Path+" result", got.Result, tc.Result)
+	}
+}
+
+func TestServeBaseRateAt(t *testing.T) {
+	fake := fakeMNB{BaseRates: make([]mnb.MNBBaseRate, 2)}
+	for i, s := range []string{"2016-05-24=0.90", "2024-04-24=7.75"} {
+		day, rate, _ := strings.Cut(s, "=")
+		if err := fake.BaseRates[i].Publication.UnmarshalText([]byte(day)); err != nil {
+			t.Fatal(err)
+		}
+		fake.BaseRates[i].Rate = testRate(t, "HUF", 1, rate).Rate
+	}
+	api := newTestAPIServer(t, &fake)
+	for _, tc := range []struct {
+		Day, Publication, Rate string
+	}{
+		{Day: "2024-05-10", Publication: "2024-04-24", Rate: "7.75"},
+		{Day: "2024-04-24", Publication: "2024-04-24", Rate: "7.75"},
+		{Day: "2024-04-23", Publication: "2016-05-24", Rate: "0.90"},
+	} {
+		var got mnb.MNBBaseRate
+		getJSON(t, api, "/baserate/at/"+tc.Day, http.StatusOK, &got)
+		if got.Publication.String() != tc.Publication {
+			t.Errorf("%s: got %s, wanted %s", tc.Day, got.Publication, tc.Publication)
+		}
+		checkDouble(t, tc.Day, got.Rate, tc.Rate)
+	}
+}
+
+func TestServeErrors(t *testing.T) {
+	fake := fakeMNB{
+		Days:      testRates(t, "2024-05-03 EUR=390.50"),
+		BaseRates: []mnb.MNBBaseRate{{Rate: testRate(t, "HUF", 1, "7.75").Rate}},
+	}
+	if err := fake.BaseRates[0].Publication.UnmarshalText([]byte("2024-04-24")); err != nil {
+		t.Fatal(err)
+	}
+	api := newTestAPIServer(t, &fake)
+	for _, tc := range []struct {
+		Path string
+		Code int
+	}{
+		{Path: "/rates?from=2024-05-03&to=2024-05-03", Code: http.StatusBadRequest},
+		{Path: "/rates?from=2024-13-01&currency=EUR", Code: http.StatusBadRequest},
+		{Path: "/rates?from=2024-05-03&to=2024-05-03&currency=EUR&format=pdf", Code: http.StatusBadRequest},
+		{Path: "/convert?amount=1", Code: http.StatusBadRequest},
+		{Path: "/convert?from=EUR&amount=x", Code: http.StatusBadRequest},
+		{Path: "/convert?from=EUR&date=2024-5-3", Code: http.StatusBadRequest},
+		{Path: "/convert?from=GBP&date=2024-05-03", Code: http.StatusNotFound},
+		{Path: "/baserate/at/tomorrow", Code: http.StatusBadRequest},
+		{Path: "/baserate/at/2000-01-01", Code: http.StatusNotFound},
+	} {
+		var got struct{ Error string }
+		getJSON(t, api, tc.Path, tc.Code, &got)
+		if got.Error == "" {
+			t.Errorf("%s: no Error", tc.Path)
+		}
+	}
+}
@@ -0,0 +1,374 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tgulacsi/mnbarf/mnb"
+)
+
+// site generates a static, browsable archive of the rates into Dir:
+//
+//	index.html                      the currencies, with links to
+//	<currency>/index.html           the years of the currency, with links to
+//	<currency>/<year>/index.html    the months and the rates of the year, with links to
+//	<currency>/<year>/<month>.html  the rates of the month
+//	baserate.html                   the base rate history
+//
+// Each page has a JSON sidecar (the same name with .json extension) with the summary and the rates.
+type site struct {
+	Dir       string
+	Days      []mnb.DayRates
+	BaseRates []mnb.MNBBaseRate
+}
+
+// sitePage is the data of a page.
+type sitePage struct {
+	Title       string
+	Breadcrumbs []siteLink
+	Chart       template.HTML
+	Summary     *reportSummary
+	// ChildrenTitle is the heading of the Children table.
+	ChildrenTitle string
+	Children      []siteChild
+	Rows          []siteRow
+	JSON          string
+}
+
+type siteLink struct {
+	Name, Href string
+}
+
+type siteChild struct {
+	Href string
+	reportSummary
+}
+
+type siteRow struct {
+	Day, Rate, Change string
+}
+
+// siteData is the JSON sidecar of a page.
+type siteData struct {
+	Currency     string `json:",omitempty"`
+	Unit         int    `json:",omitempty"`
+	Summary      mnb.Summary
+	Observations []mnb.Observation
+}
+
+// Generate writes the whole site.
+func (st site) Generate() error {
+	index := sitePage{Title: "MNB exchange rates archive", JSON: "index.json", ChildrenTitle: "Currencies"}
+	var indexData []siteData
+	for _, s := range mnb.SplitSeries(st.Days) {
+		if s.Currency == "HUF" || len(s.Observations) == 0 {
+			continue
+		}
+		child, data, err := st.writeCurrency(s)
+		if err != nil {
+			return fmt.Errorf("%s: %w", s.Currency, err)
+		}
+		index.Children = append(index.Children, child)
+		data.Observations = nil
+		indexData = append(indexData, data)
+	}
+	if len(st.BaseRates) != 0 {
+		s := mnb.BaseRateSeries(st.BaseRates)
+		sum, err := s.Summarize()
+		if err != nil {
+			return fmt.Errorf("summarize base rate: %w", err)
+		}
+		rs := newReportSummary("base rate (%)", s, sum)
+		page := sitePage{
+			Title: "MNB central bank base rate (%)", JSON: "baserate.json",
+			Breadcrumbs: []siteLink{{Name: "Index", Href: "index.html"}},
+			Chart:       svgChart(s, 720, 240), Summary: &rs,
+			Rows: siteRows(s.Observations, nil),
+		}
+		slices.Reverse(page.Rows)
+		if err := st.writePage("baserate.html", page, siteData{Summary: sum, Observations: s.Observations}); err != nil {
+			return err
+		}
+		index.Breadcrumbs = append(index.Breadcrumbs, siteLink{Name: "Base rate history", Href: "baserate.html"})
+	}
+	return st.writePage("index.html", index, indexData)
+}
+
+// writeCurrency writes the pages of the currency, and returns its summary.
+func (st site) writeCurrency(s mnb.Series) (siteChild, siteData, error) {
+	name := s.Currency
+	if s.Unit > 1 {
+		name += " (" + strconv.Itoa(s.Unit) + ")"
+	}
+	sum, err := s.Summarize()
+	if err != nil {
+		return siteChild{}, siteData{}, err
+	}
+	rs := newReportSummary(name, s, sum)
+	data := siteData{Currency: s.Currency, Unit: s.Unit, Summary: sum, Observations: s.Observations}
+	page := sitePage{
+		Title: "MNB exchange rate of " + name, JSON: "index.json",
+		Breadcrumbs: []siteLink{{Name: "Index", Href: "../index.html"}},
+		Chart:       svgChart(s, 720, 240), Summary: &rs,
+		ChildrenTitle: "Years",
+	}
+	for _, y := range splitObservations(s.Observations, "2006") {
+		ys := mnb.Series{Currency: s.Currency, Unit: s.Unit, Observations: s.Observations[y.Begin:y.End]}
+		ysum, err := ys.Summarize()
+		if err != nil {
+			return siteChild{}, siteData{}, err
+		}
+		yPage := sitePage{
+			Title: name + " " + y.Key, JSON: "index.json",
+			Breadcrumbs: []siteLink{{Name: "Index", Href: "../../index.html"}, {Name: name, Href: "../index.html"}},
+			Chart:       svgChart(ys, 720, 240), ChildrenTitle: "Months",
+			Rows: siteRows(s.Observations, &y),
+		}
+		yrs := newReportSummary(y.Key, ys, ysum)
+		yPage.Summary = &yrs
+		for _, m := range splitObservations(ys.Observations, "01") {
+			m.Begin, m.End = y.Begin+m.Begin, y.Begin+m.End
+			ms := mnb.Series{Currency: s.Currency, Unit: s.Unit, Observations: s.Observations[m.Begin:m.End]}
+			msum, err := ms.Summarize()
+			if err != nil {
+				return siteChild{}, siteData{}, err
+			}
+			mrs := newReportSummary(y.Key+"-"+m.Key, ms, msum)
+			mPage := sitePage{
+				Title: name + " " + y.Key + "-" + m.Key, JSON: m.Key + ".json",
+				Breadcrumbs: []siteLink{{Name: "Index", Href: "../../index.html"}, {Name: name, Href: "../index.html"}, {Name: y.Key, Href: "index.html"}},
+				Chart:       svgChart(ms, 720, 240), Summary: &mrs,
+				Rows: siteRows(s.Observations, &m),
+			}
+			fn := filepath.Join(s.Currency, y.Key, m.Key+".html")
+			if err := st.writePage(fn, mPage, siteData{Currency: s.Currency, Unit: s.Unit, Summary: msum, Observations: ms.Observations}); err != nil {
+				return siteChild{}, siteData{}, err
+			}
+			yPage.Children = append(yPage.Children, siteChild{Href: m.Key + ".html", reportSummary: mrs})
+		}
+		fn := filepath.Join(s.Currency, y.Key, "index.html")
+		if err := st.writePage(fn, yPage, siteData{Currency: s.Currency, Unit: s.Unit, Summary: ysum, Observations: ys.Observations}); err != nil {
+			return siteChild{}, siteData{}, err
+		}
+		page.Children = append(page.Children, siteChild{Href: y.Key + "/index.html", reportSummary: yrs})
+	}
+	slices.Reverse(page.Children)
+	if err := st.writePage(filepath.Join(s.Currency, "index.html"), page, data); err != nil {
+		return siteChild{}, siteData{}, err
+	}
+	return siteChild{Href: s.Currency + "/index.html", reportSummary: rs}, data, nil
+}
+
+// observationRange is the [Begin, End) range of the observations with the same Key.
+type observationRange struct {
+	Key        string
+	Begin, End int
+}
+
+// splitObservations splits the (ordered) observations by the day formatted with layout.
+func splitObservations(obs []mnb.Observation, layout string) []observationRange {
+	var ranges []observationRange
+	for i, o := range obs {
+		key := time.Time(o.Day).Format(layout)
+		if len(ranges) == 0 || ranges[len(ranges)-1].Key != key {
+			ranges = append(ranges, observationRange{Key: key, Begin: i})
+		}
+		ranges[len(ranges)-1].End = i + 1
+	}
+	return ranges
+}
+
+// siteRows returns the rows of the observations in the range (all if nil),
+// with the percent change since the previous observation.
+func siteRows(obs []mnb.Observation, r *observationRange) []siteRow {
+	begin, end := 0, len(obs)
+	if r != nil {
+		begin, end = r.Begin, r.End
+	}
+	rows := make([]siteRow, 0, end-begin)
+	for i := begin; i < end; i++ {
+		row := siteRow{Day: outLocale.FormatDate(obs[i].Day), Rate: outLocale.FormatDouble(obs[i].Rate)}
+		if i > 0 {
+			if pct, err := mnb.PercentChange(obs[i-1].Rate, obs[i].Rate); err == nil {
+				row.Change = outLocale.FormatDouble(pct.Round(2))
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func (st site) writePage(fn string, page sitePage, data any) error {
+	fn = filepath.Join(st.Dir, fn)
+	if err := os.MkdirAll(filepath.Dir(fn), 0750); err != nil {
+		return err
+	}
+	var buf strings.Builder
+	if err := siteTemplate.Execute(&buf, page); err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+	if err := os.WriteFile(fn, []byte(buf.String()), 0640); err != nil {
+		return err
+	}
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(strings.TrimSuffix(fn, ".html")+".json", b, 0640)
+}
+
+// svgChart returns an SVG line chart of the series, with the min and max rates and the first and last days.
+func svgChart(s mnb.Series, width, height int) template.HTML {
+	if len(s.Observations) == 0 {
+		return ""
+	}
+	const left, bottom = 64, 20
+	w, h := width-left-4, height-bottom-4
+	values := make([]float64, len(s.Observations))
+	for i, o := range s.Observations {
+		values[i] = o.Rate.Float64()
+	}
+	loI, hiI := 0, 0
+	for i, v := range values {
+		if v < values[loI] {
+			loI = i
+		}
+		if v > values[hiI] {
+			hiI = i
+		}
+	}
+	lo, hi := values[loI], values[hiI]
+	var buf strings.Builder
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %[1]d %[2]d" font-size="11" font-family="sans-serif">`, width, height)
+	fmt.Fprintf(&buf, `<rect x="%d" y="4" width="%d" height="%d" fill="none" stroke="#ccc"/>`, left, w, h)
+	fmt.Fprintf(&buf, `<text x="%d" y="14" text-anchor="end">%s</text>`, left-4, template.HTMLEscapeString(outLocale.FormatDouble(s.Observations[hiI].Rate)))
+	fmt.Fprintf(&buf, `<text x="%d" y="%d" text-anchor="end">%s</text>`, left-4, h+4, template.HTMLEscapeString(outLocale.FormatDouble(s.Observations[loI].Rate)))
+	fmt.Fprintf(&buf, `<text x="%d" y="%d">%s</text>`, left, height-4, template.HTMLEscapeString(outLocale.FormatDate(s.Observations[0].Day)))
+	fmt.Fprintf(&buf, `<text x="%d" y="%d" text-anchor="end">%s</text>`, width-4, height-4, template.HTMLEscapeString(outLocale.FormatDate(s.Observations[len(s.Observations)-1].Day)))
+	buf.WriteString(`<polyline fill="none" stroke="#1f5fa8" stroke-width="1.5" points="`)
+	for i, v := range values {
+		x := float64(left) + float64(w)/2
+		if len(values) > 1 {
+			x = float64(left) + float64(i)*float64(w)/float64(len(values)-1)
+		}
+		y := 4 + float64(h)/2
+		if hi > lo {
+			y = 4 + (hi-v)*float64(h)/(hi-lo)
+		}
+		if i != 0 {
+			buf.WriteByte(' ')
+		}
+		fmt.Fprintf(&buf, "%.1f,%.1f", x, y)
+	}
+	buf.WriteString(`"/></svg>`)
+	return template.HTML(buf.String())
+}
+
+// readStoredRates reads the rates stored by the daemon in dir, in the period, of the currencies (all if empty).
+func readStoredRates(dir string, begin, end time.Time, currencies []string) ([]mnb.DayRates, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "rates", "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var days []mnb.DayRates
+	for _, fn := range files {
+		b, err := os.ReadFile(fn)
+		if err != nil {
+			return days, err
+		}
+		var day mnb.DayRates
+		if err := json.Unmarshal(b, &day); err != nil {
+			return days, fmt.Errorf("%s: %w", fn, err)
+		}
+		if t := time.Time(day.Day); t.Before(begin) || t.After(end) {
+			continue
+		}
+		if len(currencies) != 0 {
+			day.Rates = slices.DeleteFunc(day.Rates, func(r mnb.Rate) bool { return !slices.Contains(currencies, r.Currency) })
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+// fetchRates gets the rates of the period from MNB, one year at a time.
+func fetchRates(ctx context.Context, wsC mnb.MNBArfolyamService, begin, end time.Time, currencies []string) ([]mnb.DayRates, error) {
+	var days []mnb.DayRates
+	for !begin.After(end) {
+		chunkEnd := begin.AddDate(1, 0, -1)
+		if chunkEnd.After(end) {
+			chunkEnd = end
+		}
+		chunk, err := wsC.GetExchangeRates(ctx, begin, chunkEnd, currencies...)
+		if err != nil {
+			return days, err
+		}
+		days = append(days, chunk...)
+		begin = chunkEnd.AddDate(0, 0, 1)
+	}
+	return days, nil
+}
+
+// fetchBaseRates returns the base rates published between begin and end,
+// preceded by the one in effect on begin (if it was published before), so the history starts on begin.
+func fetchBaseRates(ctx context.Context, wsR mnb.MNBAlapkamatService, begin, end time.Time) ([]mnb.MNBBaseRate, error) {
+	rates, err := wsR.GetCentralBankBaseRate(ctx, begin, end)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rates {
+		if !time.Time(r.Publication).After(begin) {
+			return rates, nil
+		}
+	}
+	first, err := wsR.GetBaseRateAt(ctx, begin)
+	if err != nil {
+		if errors.Is(err, mnb.ErrNoRate) { // begin is before the first base rate
+			return rates, nil
+		}
+		return nil, err
+	}
+	return append([]mnb.MNBBaseRate{first}, rates...), nil
+}
+
+var siteTemplate = template.Must(template.New("site").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+` + reportCSS + `</head>
+<body>
+<nav>{{range $i, $l := .Breadcrumbs}}{{if $i}} &rsaquo; {{end}}<a href="{{$l.Href}}">{{$l.Name}}</a>{{end}}</nav>
+<h1>{{.Title}}</h1>
+{{with .Chart}}<p>{{.}}</p>{{end}}
+{{with .Summary}}<table>
+<tr><th>First</th><th>Last</th><th>Min</th><th>Max</th><th>Mean</th><th>Change %</th><th>Days</th></tr>
+<tr><td class="num" title="{{.FirstDay}}">{{.First}}</td><td class="num" title="{{.LastDay}}">{{.Last}}</td><td class="num" title="{{.MinDay}}">{{.Min}} ({{.MinDay}})</td><td class="num" title="{{.MaxDay}}">{{.Max}} ({{.MaxDay}})</td><td class="num">{{.Mean}}</td><td class="num">{{.Change}}</td><td class="num">{{.Count}}</td></tr>
+</table>{{end}}
+{{if .Children}}<h2>{{.ChildrenTitle}}</h2>
+<table>
+<tr><th></th><th>First</th><th>Last</th><th>Min</th><th>Max</th><th>Mean</th><th>Change %</th><th>Days</th><th></th></tr>
+{{range .Children}}<tr><th><a href="{{.Href}}">{{.Name}}</a></th><td class="num" title="{{.FirstDay}}">{{.First}}</td><td class="num" title="{{.LastDay}}">{{.Last}}</td><td class="num" title="{{.MinDay}}">{{.Min}}</td><td class="num" title="{{.MaxDay}}">{{.Max}}</td><td class="num">{{.Mean}}</td><td class="num">{{.Change}}</td><td class="num">{{.Count}}</td><td class="spark">{{.Sparkline}}</td></tr>
+{{end}}</table>{{end}}
+{{if .Rows}}<h2>Rates</h2>
+<table>
+<tr><th>Date</th><th>Rate</th><th>Change %</th></tr>
+{{range .Rows}}<tr><td>{{.Day}}</td><td class="num">{{.Rate}}</td><td class="num">{{.Change}}</td></tr>
+{{end}}</table>{{end}}
+<p><a href="{{.JSON}}">JSON</a></p>
+</body>
+</html>
+`))
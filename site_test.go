@@ -0,0 +1,111 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tgulacsi/mnbarf/mnb"
+)
+
+func TestSiteGenerate(t *testing.T) {
+	ctx := context.Background()
+	fake := fakeMNB{
+		Days: testRates(t,
+			"2024-03-29 EUR=393.00 JPY/100=238.00",
+			"2024-04-30 EUR=392.00 JPY/100=235.10",
+			"2024-05-02 EUR=391.00 JPY/100=236.00",
+			"2024-05-03 EUR=390.50 JPY/100=236.50",
+		),
+		BaseRates: make([]mnb.MNBBaseRate, 3),
+	}
+	for i, s := range []string{"2016-05-24=0.90", "2024-04-24=7.75", "2024-05-22=7.25"} {
+		day, rate, _ := strings.Cut(s, "=")
+		if err := fake.BaseRates[i].Publication.UnmarshalText([]byte(day)); err != nil {
+			t.Fatal(err)
+		}
+		fake.BaseRates[i].Rate = testRate(t, "HUF", 1, rate).Rate
+	}
+	srv := fake.serve(t)
+	wsC, wsR := mnb.NewMNBArfolyamService(srv.URL, nil, nil), mnb.NewMNBAlapkamatService(srv.URL, nil, nil)
+
+	begin, end := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+	st := site{Dir: t.TempDir()}
+	var err error
+	if st.Days, err = fetchRates(ctx, wsC, begin, end, []string{"EUR", "JPY"}); err != nil {
+		t.Fatal(err)
+	}
+	if st.BaseRates, err = fetchBaseRates(ctx, wsR, begin, end); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.Generate(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, fn := range []string{
+		"index.html", "index.json",
+		"baserate.html", "baserate.json",
+		"EUR/index.html", "EUR/index.json",
+		"EUR/2024/index.html", "EUR/2024/index.json",
+		"EUR/2024/04.html", "EUR/2024/04.json",
+		"EUR/2024/05.html", "EUR/2024/05.json",
+		"JPY/2024/05.html",
+	} {
+		if _, err := os.Stat(filepath.Join(st.Dir, filepath.FromSlash(fn))); err != nil {
+			t.Error(err)
+		}
+	}
+	for _, fn := range []string{"HUF", "EUR/2024/03.html"} {
+		if _, err := os.Stat(filepath.Join(st.Dir, filepath.FromSlash(fn))); err == nil {
+			t.Errorf("%s should not be generated", fn)
+		}
+	}
+
+	readData := func(fn string, v any) {
+		t.Helper()
+		b, err := os.ReadFile(filepath.Join(st.Dir, filepath.FromSlash(fn)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal(b, v); err != nil {
+			t.Fatalf("%s: %+v", fn, err)
+		}
+	}
+	var index []siteData
+	readData("index.json", &index)
+	if len(index) != 2 || index[0].Currency != "EUR" || index[1].Currency != "JPY" || index[1].Unit != 100 {
+		t.Errorf("got index %+v", index)
+	}
+	var month siteData
+	readData("EUR/2024/05.json", &month)
+	if len(month.Observations) != 2 {
+		t.Fatalf("got %d observations in May, wanted 2", len(month.Observations))
+	}
+	checkDouble(t, "last EUR", month.Observations[1].Rate, "390.50")
+
+	// the base rate in effect on -from is the first one
+	var base siteData
+	readData("baserate.json", &base)
+	var days []string
+	for _, o := range base.Observations {
+		days = append(days, o.Day.String()+"="+o.Rate.String())
+	}
+	if got, want := strings.Join(days, " "), "2016-05-24=0.90 2024-04-24=7.75"; got != want {
+		t.Errorf("got base rates %s, wanted %s", got, want)
+	}
+	b, err := os.ReadFile(filepath.Join(st.Dir, "baserate.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), "2016-05-24") {
+		t.Errorf("no 2016-05-24 in baserate.html:\n%s", b)
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/tgulacsi/mnbarf/mnb"
+)
+
+// sqlOptions configures the "sql" output format.
+type sqlOptions struct {
+	// Dialect is one of postgres, oracle or sqlite.
+	Dialect string
+	// Table is the target table, defaults to mnb_rates or mnb_base_rates.
+	Table string
+	// Columns renames the logical columns (day, currency, unit, rate),
+	// as a comma separated list of name=column pairs.
+	Columns string
+	// Upsert generates ON CONFLICT (MERGE for Oracle) statements instead of plain INSERTs.
+	Upsert bool
+}
+
+var sqlOpts = sqlOptions{Dialect: "postgres"}
+
+const (
+	defaultRatesTable     = "mnb_rates"
+	defaultBaseRatesTable = "mnb_base_rates"
+)
+
+// sqlTable is a resolved target table: name, columns and the number of key columns
+// (the first keyN columns form the unique key).
+type sqlTable struct {
+	Dialect string
+	Name    string
+	Columns []string
+	KeyN    int
+}
+
+func (o sqlOptions) ratesTable() (sqlTable, error) {
+	return o.table(defaultRatesTable, []string{"day", "currency", "unit", "rate"}, 2)
+}
+func (o sqlOptions) baseRatesTable() (sqlTable, error) {
+	return o.table(defaultBaseRatesTable, []string{"day", "rate"}, 1)
+}
+
+func (o sqlOptions) table(defaultName string, names []string, keyN int) (sqlTable, error) {
+	t := sqlTable{Dialect: strings.ToLower(o.Dialect), Name: o.Table, KeyN: keyN,
+		Columns: append([]string(nil), names...)}
+	switch t.Dialect {
+	case "postgres", "oracle", "sqlite":
+	case "postgresql", "pg":
+		t.Dialect = "postgres"
+	case "sqlite3":
+		t.Dialect = "sqlite"
+	default:
+		return t, fmt.Errorf("unknown SQL dialect %q (possible: postgres, oracle, sqlite)", o.Dialect)
+	}
+	if t.Name == "" {
+		t.Name = defaultName
+	}
+	if o.Columns == "" {
+		return t, nil
+	}
+	for _, pair := range strings.Split(o.Columns, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || v == "" {
+			return t, fmt.Errorf("column mapping %q: should be name=column", pair)
+		}
+		switch k {
+		case "day", "currency", "unit", "rate":
+		default:
+			return t, fmt.Errorf("column mapping %q: unknown column %q (possible: day, currency, unit, rate)", pair, k)
+		}
+		// The base rate table has no currency and unit, so those mappings are skipped.
+		for i, nm := range names {
+			if nm == k {
+				t.Columns[i] = v
+				break
+			}
+		}
+	}
+	return t, nil
+}
+
+// Date returns the SQL literal of the date.
+func (t sqlTable) Date(d mnb.Date) string {
+	if t.Dialect == "sqlite" {
+		return "'" + d.String() + "'"
+	}
+	return "DATE '" + d.String() + "'"
+}
+
+// String returns the quoted SQL literal of s.
+func (t sqlTable) String(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// WriteStatement writes one INSERT (or upsert) statement for the given literal values.
+func (t sqlTable) WriteStatement(w io.Writer, upsert bool, values []string) error {
+	_, err := io.WriteString(w, t.statement(upsert, [][]string{values})+";\n")
+	return err
+}
+
+// statement returns one INSERT (or upsert) statement for all the rows of values
+// (literals or bind placeholders), without the terminating semicolon.
+func (t sqlTable) statement(upsert bool, rows [][]string) string {
+	cols := strings.Join(t.Columns, ", ")
+	tuples := make([]string, len(rows))
+	for i, values := range rows {
+		tuples[i] = "(" + strings.Join(values, ", ") + ")"
+	}
+	if !upsert {
+		if t.Dialect == "oracle" && len(rows) > 1 {
+			into := "INTO " + t.Name + " (" + cols + ") VALUES "
+			return "INSERT ALL " + into + strings.Join(tuples, " "+into) + " SELECT 1 FROM DUAL"
+		}
+		return fmt.Sprintf("INSERT INTO %s (%s) VALUES %s", t.Name, cols, strings.Join(tuples, ", "))
+	}
+	if t.Dialect == "oracle" {
+		selects := make([]string, len(rows))
+		src := make([]string, len(t.Columns))
+		for j, values := range rows {
+			for i, c := range t.Columns {
+				src[i] = values[i] + " AS " + c
+			}
+			selects[j] = "SELECT " + strings.Join(src, ", ") + " FROM DUAL"
+		}
+		on := make([]string, t.KeyN)
+		set := make([]string, 0, len(t.Columns)-t.KeyN)
+		ins := make([]string, len(t.Columns))
+		for i, c := range t.Columns {
+			ins[i] = "s." + c
+			if i < t.KeyN {
+				on[i] = "t." + c + " = s." + c
+			} else {
+				set = append(set, "t."+c+" = s."+c)
+			}
+		}
+		var upd string
+		if len(set) != 0 {
+			upd = " WHEN MATCHED THEN UPDATE SET " + strings.Join(set, ", ")
+		}
+		return fmt.Sprintf("MERGE INTO %s t USING (%s) s ON (%s)%s WHEN NOT MATCHED THEN INSERT (%s) VALUES (%s)",
+			t.Name, strings.Join(selects, " UNION ALL "), strings.Join(on, " AND "), upd,
+			cols, strings.Join(ins, ", "))
+	}
+
+	set := make([]string, 0, len(t.Columns)-t.KeyN)
+	for _, c := range t.Columns[t.KeyN:] {
+		set = append(set, c+" = excluded."+c)
+	}
+	action := "DO NOTHING"
+	if len(set) != 0 {
+		action = "DO UPDATE SET " + strings.Join(set, ", ")
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES %s ON CONFLICT (%s) %s",
+		t.Name, cols, strings.Join(tuples, ", "),
+		strings.Join(t.Columns[:t.KeyN], ", "), action)
+}
+
+func writeSQLDayRates(w io.Writer, days []mnb.DayRates, o sqlOptions) error {
+	t, err := o.ratesTable()
+	if err != nil {
+		return err
+	}
+	values := make([]string, 4)
+	for _, day := range days {
+		values[0] = t.Date(day.Day)
+		for _, rate := range day.Rates {
+			values[1], values[2], values[3] = t.String(rate.Currency), strconv.Itoa(rate.Unit), rate.Rate.String()
+			if err := t.WriteStatement(w, o.Upsert, values); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func writeSQLBaseRates(w io.Writer, rates []mnb.MNBBaseRate, o sqlOptions) error {
+	t, err := o.baseRatesTable()
+	if err != nil {
+		return err
+	}
+	values := make([]string, 2)
+	for _, rate := range rates {
+		values[0], values[1] = t.Date(rate.Publication), rate.Rate.String()
+		if err := t.WriteStatement(w, o.Upsert, values); err != nil {
+			return err
+		}
+	}
+	return nil
+}
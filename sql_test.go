@@ -0,0 +1,52 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPrintDayRatesSQL(t *testing.T) {
+	var buf strings.Builder
+	if err := printDayRates(&buf, testRates(t, "2024-05-03 EUR=390.50 JPY/100=236.50"), "sql"); err != nil {
+		t.Fatal(err)
+	}
+	got := buf.String()
+	if n := strings.Count(got, "INSERT INTO mnb_rates"); n != 2 {
+		t.Errorf("got %d INSERTs, wanted 2:\n%s", n, got)
+	}
+	if strings.Contains(got, "'HUF'") {
+		t.Errorf("HUF is not loaded by the load command, should not be in the sql output:\n%s", got)
+	}
+	for _, want := range []string{"'EUR', 1, 390.50", "'JPY', 100, 236.50"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("no %q in\n%s", want, got)
+		}
+	}
+}
+
+func TestSQLTableStatement(t *testing.T) {
+	rows := [][]string{{":1", ":2"}, {":3", ":4"}}
+	for _, tc := range []struct {
+		Dialect string
+		Upsert  bool
+		Want    string
+	}{
+		{Dialect: "postgres", Want: "INSERT INTO mnb_base_rates (day, rate) VALUES (:1, :2), (:3, :4)"},
+		{Dialect: "postgres", Upsert: true, Want: "INSERT INTO mnb_base_rates (day, rate) VALUES (:1, :2), (:3, :4) ON CONFLICT (day) DO UPDATE SET rate = excluded.rate"},
+		{Dialect: "oracle", Want: "INSERT ALL INTO mnb_base_rates (day, rate) VALUES (:1, :2) INTO mnb_base_rates (day, rate) VALUES (:3, :4) SELECT 1 FROM DUAL"},
+		{Dialect: "oracle", Upsert: true, Want: "MERGE INTO mnb_base_rates t USING (SELECT :1 AS day, :2 AS rate FROM DUAL UNION ALL SELECT :3 AS day, :4 AS rate FROM DUAL) s ON (t.day = s.day)" +
+			" WHEN MATCHED THEN UPDATE SET t.rate = s.rate WHEN NOT MATCHED THEN INSERT (day, rate) VALUES (s.day, s.rate)"},
+	} {
+		tbl, err := sqlOptions{Dialect: tc.Dialect}.baseRatesTable()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := tbl.statement(tc.Upsert, rows); got != tc.Want {
+			t.Errorf("%s upsert=%t:\ngot  %s\nwant %s", tc.Dialect, tc.Upsert, got, tc.Want)
+		}
+	}
+}
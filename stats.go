@@ -0,0 +1,68 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/tgulacsi/mnbarf/mnb"
+)
+
+// printAggregates prints the per-period statistics in the output format.
+func printAggregates(w io.Writer, aggs []mnb.Aggregate, period mnb.Period, outFormat string) error {
+	bw := bufio.NewWriter(w)
+	defer bw.Flush()
+
+	switch outFormat {
+	case "json":
+		enc := json.NewEncoder(bw)
+		enc.SetIndent("", "  ")
+		return enc.Encode(aggs)
+
+	case "csv", "html", "markdown", "md":
+		t := table{
+			Title: "MNB exchange rates by " + string(period),
+			Columns: []string{"period", "currency", "unit", "count", "mean",
+				"first_day", "first", "last_day", "last", "min_day", "min", "max_day", "max", "change(%)"},
+		}
+		for _, a := range aggs {
+			t.Rows = append(t.Rows, []string{
+				a.Period, a.Currency, strconv.Itoa(a.Unit), strconv.Itoa(a.Count),
+				outLocale.FormatDouble(a.Mean.Round(4)),
+				outLocale.FormatDate(a.First.Day), outLocale.FormatDouble(a.First.Rate),
+				outLocale.FormatDate(a.Last.Day), outLocale.FormatDouble(a.Last.Rate),
+				outLocale.FormatDate(a.Min.Day), outLocale.FormatDouble(a.Min.Rate),
+				outLocale.FormatDate(a.Max.Day), outLocale.FormatDouble(a.Max.Rate),
+				outLocale.FormatDouble(a.Change.Round(2)),
+			})
+		}
+		switch outFormat {
+		case "csv":
+			return t.WriteCSV(bw)
+		case "html":
+			return t.WriteHTML(bw)
+		}
+		return t.WriteMarkdown(bw)
+
+	case "sql":
+		return fmt.Errorf("the sql format is not supported for statistics")
+
+	default: // template
+		tmpl, err := parseOutTemplate(outFormat)
+		if err != nil {
+			logger.Info("template parse", "error", err)
+			return err
+		}
+		if err := executeOutTemplate(bw, tmpl, aggs, aggs); err != nil {
+			logger.Info("template execute", "error", err)
+			return err
+		}
+	}
+	return nil
+}
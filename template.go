@@ -0,0 +1,159 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/cockroachdb/apd/v3"
+	"github.com/tgulacsi/mnbarf/mnb"
+)
+
+var templateFuncs = template.FuncMap{
+	"add": func(a, b any) (mnb.Double, error) { return decimalOp(mnb.DecimalContext.Add, a, b) },
+	"sub": func(a, b any) (mnb.Double, error) { return decimalOp(mnb.DecimalContext.Sub, a, b) },
+	"mul": func(a, b any) (mnb.Double, error) { return decimalOp(mnb.DecimalContext.Mul, a, b) },
+	"div": func(a, b any) (mnb.Double, error) { return decimalOp(quo, a, b) },
+	// perUnit returns the rate of one unit of the currency (JPY is quoted for 100 units).
+	"perUnit": func(rate, unit any) (mnb.Double, error) { return decimalOp(quo, rate, unit) },
+	"round": func(places int32, x any) (mnb.Double, error) {
+		d, err := toDecimal(x)
+		if err != nil {
+			return mnb.Double{}, err
+		}
+		var z apd.Decimal
+		_, err = mnb.DecimalContext.Quantize(&z, d, -places)
+		return mnb.Double{Decimal: &z}, err
+	},
+	"decimal": func(x any) (mnb.Double, error) {
+		d, err := toDecimal(x)
+		return mnb.Double{Decimal: d}, err
+	},
+	// date formats the date with the Go time layout.
+	"date": func(layout string, d any) (string, error) {
+		switch x := d.(type) {
+		case mnb.Date:
+			return time.Time(x).Format(layout), nil
+		case time.Time:
+			return x.Format(layout), nil
+		case string:
+			t, err := time.Parse("2006-01-02", x)
+			return t.Format(layout), err
+		}
+		return "", fmt.Errorf("date: unknown type %T", d)
+	},
+	"localNumber": func(x any) (string, error) {
+		d, err := toDecimal(x)
+		return outLocale.FormatDouble(mnb.Double{Decimal: d}), err
+	},
+	"localDate": func(d mnb.Date) string { return outLocale.FormatDate(d) },
+	// hu returns the number with decimal comma.
+	"hu": func(x any) (string, error) {
+		d, err := toDecimal(x)
+		if err != nil {
+			return "", err
+		}
+		return strings.Replace(d.Text('f'), ".", ",", 1), nil
+	},
+}
+
+func decimalOp(op func(z, x, y *apd.Decimal) (apd.Condition, error), a, b any) (mnb.Double, error) {
+	x, err := toDecimal(a)
+	if err != nil {
+		return mnb.Double{}, err
+	}
+	y, err := toDecimal(b)
+	if err != nil {
+		return mnb.Double{}, err
+	}
+	var z apd.Decimal
+	_, err = op(&z, x, y)
+	return mnb.Double{Decimal: &z}, err
+}
+
+// quo divides without the trailing zeros of the full precision.
+func quo(z, x, y *apd.Decimal) (apd.Condition, error) {
+	res, err := mnb.DecimalContext.Quo(z, x, y)
+	if err == nil {
+		z.Reduce(z)
+	}
+	return res, err
+}
+
+func toDecimal(v any) (*apd.Decimal, error) {
+	switch x := v.(type) {
+	case mnb.Double:
+		if x.Decimal == nil {
+			return apd.New(0, 0), nil
+		}
+		return x.Decimal, nil
+	case *apd.Decimal:
+		return x, nil
+	case apd.Decimal:
+		return &x, nil
+	case int:
+		return apd.New(int64(x), 0), nil
+	case int32:
+		return apd.New(int64(x), 0), nil
+	case int64:
+		return apd.New(x, 0), nil
+	case float64:
+		var d apd.Decimal
+		_, err := d.SetFloat64(x)
+		return &d, err
+	case string:
+		d, _, err := apd.NewFromString(strings.Replace(x, ",", ".", 1))
+		return d, err
+	}
+	return nil, fmt.Errorf("%v (%T) is not a number", v, v)
+}
+
+// parseOutTemplate parses the -format template.
+//
+// "@file.tmpl" reads the file, which may define "header", "row" and "footer" templates -
+// if there's no "row" template, the whole file is executed once with all the data.
+// Anything else is a single "row" template.
+func parseOutTemplate(format string) (*template.Template, error) {
+	fn, ok := strings.CutPrefix(format, "@")
+	if !ok {
+		return template.New("row").Funcs(templateFuncs).Parse(format)
+	}
+	b, err := os.ReadFile(fn)
+	if err != nil {
+		return nil, err
+	}
+	return template.New(filepath.Base(fn)).Funcs(templateFuncs).Parse(string(b))
+}
+
+// executeOutTemplate executes the header and footer templates with all the data,
+// and the row template for each row.
+func executeOutTemplate[T any](w io.Writer, tmpl *template.Template, all any, rows []T) error {
+	if t := tmpl.Lookup("header"); t != nil {
+		if err := t.Execute(w, all); err != nil {
+			return err
+		}
+	}
+	if t := tmpl.Lookup("row"); t == nil {
+		if err := tmpl.Execute(w, all); err != nil {
+			return err
+		}
+	} else {
+		for _, row := range rows {
+			if err := t.Execute(w, row); err != nil {
+				return fmt.Errorf("%+v: %w", row, err)
+			}
+		}
+	}
+	if t := tmpl.Lookup("footer"); t != nil {
+		return t.Execute(w, all)
+	}
+	return nil
+}
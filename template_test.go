@@ -0,0 +1,124 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tgulacsi/mnbarf/mnb"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	loc, err := mnb.NewLocale("en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func(old mnb.Locale) { outLocale = old }(outLocale)
+	outLocale = loc
+
+	rows := []mnb.DayRates{testDay(t, "2024-05-03", testRate(t, "JPY", 100, "236.50"))}
+	row := struct {
+		Date    mnb.Date
+		Decimal mnb.Double
+		Unit    int
+	}{Date: rows[0].Day, Decimal: rows[0].Rates[0].Rate, Unit: rows[0].Rates[0].Unit}
+	for _, tc := range []struct {
+		Template, Want string
+	}{
+		{Template: `{{add .Decimal 1}}`, Want: "237.50"},
+		{Template: `{{sub .Decimal "0.5"}}`, Want: "236.00"},
+		{Template: `{{mul .Decimal 2}}`, Want: "473.00"},
+		{Template: `{{div .Decimal 4}}`, Want: "59.125"},
+		{Template: `{{perUnit .Decimal .Unit}}`, Want: "2.365"},
+		{Template: `{{round 1 .Decimal}}`, Want: "236.5"},
+		{Template: `{{round 0 (div 1 3)}}`, Want: "0"},
+		{Template: `{{decimal "1,5"}}`, Want: "1.5"},
+		{Template: `{{date "2006.01.02." .Date}}`, Want: "2024.05.03."},
+		{Template: `{{date "Jan 2" "2024-05-03"}}`, Want: "May 3"},
+		{Template: `{{localNumber (mul .Decimal 10)}}`, Want: loc.FormatDouble(testRate(t, "JPY", 100, "2365.00").Rate)},
+		{Template: `{{localDate .Date}}`, Want: loc.FormatDate(row.Date)},
+		{Template: `{{hu .Decimal}}`, Want: "236,50"},
+		{Template: `{{hu 1.25}}`, Want: "1,25"},
+	} {
+		tmpl, err := parseOutTemplate(tc.Template)
+		if err != nil {
+			t.Fatalf("%s: %+v", tc.Template, err)
+		}
+		var buf strings.Builder
+		if err := tmpl.Execute(&buf, row); err != nil {
+			t.Errorf("%s: %+v", tc.Template, err)
+			continue
+		}
+		if got := buf.String(); got != tc.Want {
+			t.Errorf("%s: got %q, wanted %q", tc.Template, got, tc.Want)
+		}
+	}
+
+	for _, s := range []string{`{{add "x" 1}}`, `{{date "2006" 1}}`} {
+		tmpl, err := parseOutTemplate(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := tmpl.Execute(new(strings.Builder), row); err == nil {
+			t.Errorf("%s: wanted an error", s)
+		}
+	}
+}
+
+func TestOutTemplateFile(t *testing.T) {
+	dir := t.TempDir()
+	for _, tc := range []struct {
+		Name, Template, Want string
+	}{
+		{Name: "rows",
+			Template: `{{define "footer"}}end
+{{end}}{{define "row"}}{{.Day}} {{.Currency}} {{hu .Decimal}}
+{{end}}{{define "header"}}days={{len .}}
+{{end}}`,
+			Want: "days=2\n2024-05-02 EUR 392,00\n2024-05-02 HUF 1\n2024-05-03 EUR 390,50\n2024-05-03 JPY 236,50\n2024-05-03 HUF 1\nend\n"},
+		{Name: "whole",
+			Template: `{{range .}}{{.Day}}:{{len .Rates}} {{end}}`,
+			Want:     "2024-05-02:2 2024-05-03:3 "},
+	} {
+		fn := filepath.Join(dir, tc.Name+".tmpl")
+		if err := os.WriteFile(fn, []byte(tc.Template), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		// printDayRates appends the HUF rates to the days
+		days := testRates(t, "2024-05-02 EUR=392.00", "2024-05-03 EUR=390.50 JPY/100=236.50")
+		var buf strings.Builder
+		if err := printDayRates(&buf, days, "@"+fn); err != nil {
+			t.Fatalf("%s: %+v", tc.Name, err)
+		}
+		if got := buf.String(); got != tc.Want {
+			t.Errorf("%s: got %q, wanted %q", tc.Name, got, tc.Want)
+		}
+	}
+
+	if _, err := parseOutTemplate("@" + filepath.Join(dir, "nonexistent.tmpl")); err == nil {
+		t.Error("wanted an error for a missing template file")
+	}
+}
+
+func TestBaseRatesTemplate(t *testing.T) {
+	rates := make([]mnb.MNBBaseRate, 2)
+	for i, s := range []string{"2024-04-24=7.75", "2024-05-22=7.25"} {
+		day, rate, _ := strings.Cut(s, "=")
+		if err := rates[i].Publication.UnmarshalText([]byte(day)); err != nil {
+			t.Fatal(err)
+		}
+		rates[i].Rate = testRate(t, "HUF", 1, rate).Rate
+	}
+	var buf strings.Builder
+	if err := printBaseRates(&buf, rates, `{{.Publication}}={{.Rate}} {{.Day}} {{date "2006.01.02." .Date}} {{hu .Decimal}}|`); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "2024-04-24=7.75 2024-04-24 2024.04.24. 7,75|2024-05-22=7.25 2024-05-22 2024.05.22. 7,25|"; got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
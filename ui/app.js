@@ -0,0 +1,237 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// The UI of mnbarf serve: it uses only the JSON API, which is served one level up.
+"use strict";
+
+const api = new URL("../", location.href);
+const colors = ["#1f5fa8", "#d9480f", "#2b8a3e", "#862e9c", "#e67700", "#0b7285", "#c2255c", "#5c940d", "#495057", "#364fc7"];
+const $ = (id) => document.getElementById(id);
+
+let state = { days: [], series: [], hidden: new Set() };
+
+async function getJSON(path, params) {
+	const u = new URL(path, api);
+	for (const [k, v] of Object.entries(params || {})) {
+		if (v !== undefined && v !== "") u.searchParams.set(k, v);
+	}
+	const resp = await fetch(u);
+	const data = await resp.json();
+	if (!resp.ok) throw new Error(data.Error || resp.statusText);
+	return data;
+}
+
+function showError(err) {
+	$("error").textContent = err ? String(err.message || err) : "";
+	$("error").hidden = !err;
+}
+
+function isoDay(d) { return d.toISOString().slice(0, 10); }
+
+function selectedCurrencies() {
+	return Array.from($("currencies").selectedOptions, (o) => o.value);
+}
+
+function ratesParams(format) {
+	return { from: $("from").value, to: $("to").value, currency: selectedCurrencies().join(","), format: format };
+}
+
+function updateDownloads() {
+	for (const format of ["csv", "xlsx"]) {
+		const u = new URL("rates", api);
+		for (const [k, v] of Object.entries(ratesParams(format))) u.searchParams.set(k, v);
+		$("download-" + format).href = u;
+	}
+}
+
+// splitSeries returns the per unit rates of each currency, in ascending day order.
+function splitSeries(days) {
+	const byCurr = new Map();
+	for (const day of days) {
+		for (const r of day.Rates) {
+			if (!byCurr.has(r.Currency)) byCurr.set(r.Currency, { currency: r.Currency, unit: r.Unit, points: [] });
+			byCurr.get(r.Currency).points.push({ day: day.Day, t: Date.parse(day.Day), rate: r.Rate, value: Number(r.Rate) / r.Unit });
+		}
+	}
+	const series = Array.from(byCurr.values()).sort((a, b) => a.currency.localeCompare(b.currency));
+	series.forEach((s, i) => {
+		s.points.sort((a, b) => a.t - b.t);
+		s.color = colors[i % colors.length];
+	});
+	return series;
+}
+
+function svgEl(name, attrs, text) {
+	const el = document.createElementNS("http://www.w3.org/2000/svg", name);
+	for (const [k, v] of Object.entries(attrs)) el.setAttribute(k, v);
+	if (text !== undefined) el.textContent = text;
+	return el;
+}
+
+function fmt(v) {
+	return v.toLocaleString(undefined, { maximumFractionDigits: 4 });
+}
+
+function drawChart() {
+	const chart = $("chart");
+	chart.replaceChildren();
+	const relative = $("relative").checked;
+	const series = state.series.filter((s) => !state.hidden.has(s.currency) && s.points.length);
+	const legend = $("legend");
+	legend.replaceChildren();
+	for (const s of state.series) {
+		const span = document.createElement("span");
+		span.className = state.hidden.has(s.currency) ? "off" : "";
+		span.innerHTML = `<i style="background:${s.color}"></i>`;
+		span.append(s.currency + (s.unit > 1 ? ` (per unit of ${s.unit})` : ""));
+		span.onclick = () => {
+			state.hidden.has(s.currency) ? state.hidden.delete(s.currency) : state.hidden.add(s.currency);
+			drawChart();
+		};
+		legend.append(span);
+	}
+	if (!series.length) return;
+
+	const W = 960, H = 360, left = 64, right = 8, top = 8, bottom = 24;
+	const value = (s, p) => (relative ? (p.value / s.points[0].value) * 100 : p.value);
+	let tMin = Infinity, tMax = -Infinity, vMin = Infinity, vMax = -Infinity;
+	for (const s of series) {
+		for (const p of s.points) {
+			const v = value(s, p);
+			tMin = Math.min(tMin, p.t); tMax = Math.max(tMax, p.t);
+			vMin = Math.min(vMin, v); vMax = Math.max(vMax, v);
+		}
+	}
+	if (vMin === vMax) { vMin -= 1; vMax += 1; }
+	const x = (t) => left + (tMax > tMin ? ((t - tMin) / (tMax - tMin)) * (W - left - right) : (W - left - right) / 2);
+	const y = (v) => top + ((vMax - v) / (vMax - vMin)) * (H - top - bottom);
+
+	const svg = svgEl("svg", { viewBox: `0 0 ${W} ${H}`, xmlns: "http://www.w3.org/2000/svg" });
+	for (let i = 0; i <= 4; i++) {
+		const v = vMin + ((vMax - vMin) * i) / 4;
+		svg.append(svgEl("line", { x1: left, x2: W - right, y1: y(v), y2: y(v), stroke: "#eee" }));
+		svg.append(svgEl("text", { x: left - 4, y: y(v) + 4, "text-anchor": "end" }, fmt(v)));
+	}
+	svg.append(svgEl("text", { x: left, y: H - 6 }, isoDay(new Date(tMin))));
+	svg.append(svgEl("text", { x: W - right, y: H - 6, "text-anchor": "end" }, isoDay(new Date(tMax))));
+	for (const s of series) {
+		const points = s.points.map((p) => `${x(p.t).toFixed(1)},${y(value(s, p)).toFixed(1)}`).join(" ");
+		svg.append(svgEl("polyline", { points: points, fill: "none", stroke: s.color, "stroke-width": 1.5 }));
+	}
+	const cursor = svgEl("line", { y1: top, y2: H - bottom, stroke: "#999", visibility: "hidden" });
+	svg.append(cursor);
+	chart.append(svg);
+
+	// the tooltip shows the values of the nearest day
+	const tooltip = $("tooltip");
+	svg.onmousemove = (ev) => {
+		const rect = svg.getBoundingClientRect();
+		const px = ((ev.clientX - rect.left) / rect.width) * W;
+		const t = tMin + ((px - left) / (W - left - right)) * (tMax - tMin);
+		let best = null;
+		for (const s of series) {
+			for (const p of s.points) {
+				if (!best || Math.abs(p.t - t) < Math.abs(best.t - t)) best = p;
+			}
+		}
+		if (!best) return;
+		cursor.setAttribute("x1", x(best.t));
+		cursor.setAttribute("x2", x(best.t));
+		cursor.setAttribute("visibility", "visible");
+		const lines = [best.day];
+		for (const s of series) {
+			const p = s.points.find((p) => p.t === best.t);
+			if (p) lines.push(`${s.currency}: ${p.rate}` + (relative ? ` (${fmt(value(s, p))})` : ""));
+		}
+		tooltip.textContent = "";
+		lines.forEach((l, i) => { if (i) tooltip.append(document.createElement("br")); tooltip.append(l); });
+		tooltip.hidden = false;
+		const cx = ((x(best.t) / W) * rect.width);
+		tooltip.style.left = (cx + 12 > rect.width - tooltip.offsetWidth ? cx - tooltip.offsetWidth - 12 : cx + 12) + "px";
+		tooltip.style.top = (ev.clientY - rect.top) + "px";
+	};
+	svg.onmouseleave = () => {
+		tooltip.hidden = true;
+		cursor.setAttribute("visibility", "hidden");
+	};
+}
+
+function drawTable() {
+	const table = $("table");
+	table.replaceChildren();
+	if (!state.series.length) return;
+	const head = table.insertRow();
+	head.append(Object.assign(document.createElement("th"), { textContent: "Date" }));
+	for (const s of state.series) {
+		head.append(Object.assign(document.createElement("th"), { textContent: s.currency + (s.unit > 1 ? ` (${s.unit})` : "") }));
+	}
+	const days = [...state.days].sort((a, b) => b.Day.localeCompare(a.Day));
+	for (const day of days) {
+		const row = table.insertRow();
+		row.insertCell().textContent = day.Day;
+		for (const s of state.series) {
+			const r = day.Rates.find((r) => r.Currency === s.currency);
+			const cell = row.insertCell();
+			cell.className = "num";
+			cell.textContent = r ? r.Rate : "";
+		}
+	}
+}
+
+async function loadRates() {
+	showError(null);
+	updateDownloads();
+	const currencies = selectedCurrencies();
+	if (!currencies.length) {
+		showError("Select at least one currency.");
+		return;
+	}
+	try {
+		state.days = await getJSON("rates", ratesParams());
+		state.series = splitSeries(state.days);
+		drawChart();
+		drawTable();
+	} catch (err) {
+		showError(err);
+	}
+}
+
+async function convert() {
+	showError(null);
+	try {
+		const c = await getJSON("convert", {
+			amount: $("amount").value, from: $("convert-from").value, to: $("convert-to").value, date: $("convert-date").value,
+		});
+		$("convert-result").textContent = `${c.Amount} ${c.From} = ${c.Result} ${c.To} (rate ${c.Rate}, ${c.Day})`;
+	} catch (err) {
+		showError(err);
+	}
+}
+
+async function init() {
+	const today = new Date();
+	$("to").value = isoDay(today);
+	$("from").value = isoDay(new Date(today.getTime() - 30 * 86400e3));
+	$("rates-form").onsubmit = (ev) => { ev.preventDefault(); loadRates(); };
+	$("convert-form").onsubmit = (ev) => { ev.preventDefault(); convert(); };
+	$("relative").onchange = drawChart;
+	for (const id of ["from", "to", "currencies"]) $(id).onchange = updateDownloads;
+	try {
+		const currencies = (await getJSON("currencies")).filter((c) => c);
+		for (const c of currencies) {
+			if (c !== "HUF") $("currencies").append(new Option(c, c, false, c === "EUR" || c === "USD"));
+			$("convert-from").append(new Option(c, c, false, c === "EUR"));
+			$("convert-to").append(new Option(c, c, false, c === "HUF"));
+		}
+		if (!currencies.includes("HUF")) $("convert-to").append(new Option("HUF", "HUF", true, true));
+		const current = await getJSON("rates/current");
+		$("current").textContent = "Last publication: " + current.Day;
+	} catch (err) {
+		showError(err);
+	}
+	$("relative").checked = selectedCurrencies().length > 1;
+	loadRates();
+}
+
+init();
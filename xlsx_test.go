@@ -0,0 +1,125 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/tgulacsi/mnbarf/mnb"
+)
+
+func TestXLSXColumn(t *testing.T) {
+	for i, want := range map[int]string{
+		0: "A", 1: "B", 25: "Z", 26: "AA", 27: "AB", 51: "AZ", 52: "BA",
+		701: "ZZ", 702: "AAA", 16383: "XFD",
+	} {
+		if got := xlsxColumn(i); got != want {
+			t.Errorf("%d: got %q, wanted %q", i, got, want)
+		}
+	}
+}
+
+func TestWriteXLSX(t *testing.T) {
+	var day mnb.Date
+	if err := day.UnmarshalText([]byte("2024-05-03")); err != nil {
+		t.Fatal(err)
+	}
+	header := make([]any, 28)
+	for i := range header {
+		header[i] = xlsxColumn(i)
+	}
+	rows := [][]any{
+		header,
+		{day, "EUR & <co>", 1, testRate(t, "EUR", 1, "390.50").Rate, nil, mnb.Double{}},
+	}
+	var buf bytes.Buffer
+	if err := writeXLSX(&buf, "Rates & more", rows); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := make(map[string][]byte)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("%s: %+v", f.Name, err)
+		}
+		files[f.Name] = b
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/_rels/workbook.xml.rels", "xl/workbook.xml", "xl/styles.xml"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("no %s in %v", name, zr.File)
+			continue
+		}
+		if err := xml.Unmarshal(files[name], new(struct{})); err != nil {
+			t.Errorf("%s: %+v", name, err)
+		}
+	}
+	var workbook struct {
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := xml.Unmarshal(files["xl/workbook.xml"], &workbook); err != nil {
+		t.Fatal(err)
+	}
+	if len(workbook.Sheets) != 1 || workbook.Sheets[0].Name != "Rates & more" {
+		t.Errorf("got sheets %+v", workbook.Sheets)
+	}
+
+	var sheet struct {
+		Rows []struct {
+			R     string `xml:"r,attr"`
+			Cells []struct {
+				R      string `xml:"r,attr"`
+				T      string `xml:"t,attr"`
+				S      string `xml:"s,attr"`
+				V      string `xml:"v"`
+				Inline string `xml:"is>t"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err := xml.Unmarshal(files["xl/worksheets/sheet1.xml"], &sheet); err != nil {
+		t.Fatal(err)
+	}
+	if len(sheet.Rows) != 2 {
+		t.Fatalf("got %d rows, wanted 2", len(sheet.Rows))
+	}
+	var got []string
+	for _, row := range sheet.Rows {
+		var cells []string
+		for _, c := range row.Cells {
+			cells = append(cells, c.R+"/"+c.T+"/"+c.S+"="+c.V+c.Inline)
+		}
+		got = append(got, row.R+": "+strings.Join(cells, " "))
+	}
+	if got, want := got[0], "1: A1/inlineStr/=A B1/inlineStr/=B"; !strings.HasPrefix(got, want) {
+		t.Errorf("got header %q, wanted %q...", got, want)
+	}
+	if got, want := got[0], "Z1/inlineStr/=Z AA1/inlineStr/=AA AB1/inlineStr/=AB"; !strings.HasSuffix(got, want) {
+		t.Errorf("got header %q, wanted ...%q", got, want)
+	}
+	// nil cells and nil decimals are skipped; 2024-05-03 is day 45415 of the spreadsheets
+	if got, want := got[1], "2: A2//1=45415 B2/inlineStr/=EUR & <co> C2//=1 D2//=390.50"; got != want {
+		t.Errorf("got row %q, wanted %q", got, want)
+	}
+
+	if err := writeXLSX(io.Discard, "x", [][]any{{1.5}}); err == nil {
+		t.Error("wanted an error for a float64 cell")
+	}
+}